@@ -6,33 +6,20 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
 	"time"
 
 	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp"
 	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
 	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/ports"
 	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/services"
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/storage/filestore"
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/storage/seen"
 	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/transport/webhook"
 )
 
-type fileMan struct {
-}
-
-func (f fileMan) SetData(ctx context.Context, data []byte) error {
-	//TODO implement me
-	panic("implement me")
-}
-
-func (f fileMan) Save(ctx context.Context, fileName string) error {
-	//TODO implement me
-	panic("implement me")
-}
-
-func (f fileMan) Open(ctx context.Context, fileName string) ([]byte, error) {
-	//TODO implement me
-	panic("implement me")
-}
-
 // Minimal SecretsProvider backed by environment variables for the example only.
 type envSecrets struct{}
 
@@ -56,11 +43,24 @@ func (lh logHandler) Always(e domain.WebhookEvent, h http.Header) {
 
 func (lh logHandler) OnMessage(m domain.InboundMessage, e domain.WebhookEvent, h http.Header) {
 	log.Printf("incoming message id=%s", m.ID)
-	fm, err := lh.c.Media.GetMedia(context.Background(), m, fileMan{})
+	if m.Media() == nil {
+		return
+	}
+	// One store per download: the staged data of a FileManagerAPI is shared.
+	fm, err := filestore.NewDir(filepath.Join(os.TempDir(), "wa-media"))
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	media, err := lh.c.Media.GetMedia(context.Background(), m, fm)
 	if err != nil {
 		log.Println(err)
+		return
 	}
-	log.Printf("media info: %+v", fm)
+	if err := media.File.Save(context.Background(), media.ID); err != nil {
+		log.Println(err)
+	}
+	log.Printf("media info: %+v", media)
 }
 func (lh logHandler) OnStatus(s domain.MessageStatus, e domain.WebhookEvent, h http.Header) {
 	log.Printf("status id=%s status=%s", s.ID, s.Status)
@@ -89,10 +89,35 @@ func main() {
 		panic(err)
 
 	}
-	dispatcher := services.NewWebhookDispatcher(logHandler{c: c})
-	http.Handle("/webhook", webhook.NewHandler(svc, dispatcher))
-
-	addr := ":8080"
-	log.Printf("listening on %s", addr)
-	log.Fatal(http.ListenAndServe(addr, nil))
+	// Meta redelivers webhooks; skip messages and statuses already handled.
+	dispatcher := services.NewWebhookDispatcher(logHandler{c: c}).WithDedup(seen.NewLRU(0), 0)
+	// Acknowledge events immediately and handle them on a worker pool; when the
+	// queue is full Meta gets a 503 and redelivers later.
+	async := services.NewAsyncDispatcher(dispatcher, services.AsyncDispatcherOptions{
+		Backpressure: services.BackpressureReject,
+	})
+	mux := http.NewServeMux()
+	mux.Handle("/webhook", webhook.NewHandler(svc, nil).WithAsync(async))
+
+	srv := &http.Server{Addr: ":8080", Handler: mux}
+	go func() {
+		log.Printf("listening on %s", srv.Addr)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatal(err)
+		}
+	}()
+
+	stop, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	<-stop.Done()
+
+	ctx, done := context.WithTimeout(context.Background(), 30*time.Second)
+	defer done()
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Println(err)
+	}
+	// Let queued events finish before exiting.
+	if err := async.Shutdown(ctx); err != nil {
+		log.Println(err)
+	}
 }
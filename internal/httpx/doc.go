@@ -5,5 +5,7 @@
 //     global http.Client.Timeout to preserve streaming behavior.
 //   - Retries only occur for retryable responses (429/5xx) or network
 //     timeouts marked by net.Error. Request bodies must be rewindable.
+//   - Waits between attempts follow Retry-After or Meta's usage headers when
+//     present, exponential backoff otherwise, and end early when ctx is done.
 //   - No external dependencies; callers can wrap with their own telemetry.
 package httpx
@@ -28,6 +28,21 @@ type Options struct {
 	MaxBackoff time.Duration
 	// RetryPolicy determines which statuses are retryable. If nil, DefaultRetryPolicy is used.
 	RetryPolicy RetryPolicy
+	// MaxRetryAfter caps delays requested by the server (Retry-After or Meta's
+	// estimated_time_to_regain_access). A longer requested delay is not waited
+	// for: the response is returned as-is. Default 30s when zero.
+	MaxRetryAfter time.Duration
+	// OnRetry, when set, is called before waiting for each retry.
+	OnRetry func(RetryInfo)
+}
+
+// RetryInfo describes a retry about to happen.
+type RetryInfo struct {
+	Request    *http.Request
+	Attempt    int           // 1 for the first retry
+	Delay      time.Duration // wait before the retry
+	StatusCode int           // status of the failed attempt; 0 on network errors
+	Err        error         // network error of the failed attempt, if any
 }
 
 // Doer implements a context-aware HTTP executor with basic retry and jitter.
@@ -37,6 +52,8 @@ type Doer struct {
 	baseBackoff time.Duration
 	maxBackoff  time.Duration
 	shouldRetry RetryPolicy
+	maxWait     time.Duration
+	onRetry     func(RetryInfo)
 }
 
 // New creates a new Doer with the provided options.
@@ -61,12 +78,18 @@ func New(opts Options) *Doer {
 	if pol == nil {
 		pol = DefaultRetryPolicy
 	}
+	mw := opts.MaxRetryAfter
+	if mw <= 0 {
+		mw = 30 * time.Second
+	}
 	return &Doer{
 		client:      &http.Client{Transport: tr},
 		maxRetries:  maxRetries,
 		baseBackoff: bb,
 		maxBackoff:  mb,
 		shouldRetry: pol,
+		maxWait:     mw,
+		onRetry:     opts.OnRetry,
 	}
 }
 
@@ -84,6 +107,8 @@ func DefaultRetryPolicy(status int) bool {
 // Do executes req with retry for retryable statuses. It honors ctx for timeout/cancel.
 // IMPORTANT: req.Body must be rewindable for retries. The caller should set
 // GetBody on the request (Go 1.19+) or provide a fresh request per attempt.
+// Without it a request with a body is sent once, and Do returns the first
+// response (or error) as is, without waiting out a backoff.
 func (d *Doer) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
 	if req == nil {
 		return nil, errors.New("httpx: nil request")
@@ -93,6 +118,7 @@ func (d *Doer) Do(ctx context.Context, req *http.Request) (*http.Response, error
 
 	var lastErr error
 	var resp *http.Response
+	rewindable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
 
 	for attempt := 0; attempt <= d.maxRetries; attempt++ {
 		// Rewind body if necessary and possible.
@@ -112,30 +138,54 @@ func (d *Doer) Do(ctx context.Context, req *http.Request) (*http.Response, error
 		resp, lastErr = d.client.Do(req)
 		if lastErr != nil {
 			// Network or context error – do not blindly retry on permanent failures.
-			if isTempOrTimeout(lastErr) && attempt < d.maxRetries {
-				d.sleep(backoff(attempt, d.baseBackoff, d.maxBackoff))
+			if rewindable && isTempOrTimeout(lastErr) && attempt < d.maxRetries && ctx.Err() == nil {
+				wait := backoff(attempt, d.baseBackoff, d.maxBackoff)
+				if err := d.wait(ctx, RetryInfo{Request: req, Attempt: attempt + 1, Delay: wait, Err: lastErr}); err != nil {
+					return nil, err
+				}
 				continue
 			}
 			return nil, lastErr
 		}
 
 		// If non-nil response, decide on retry based on status code.
-		if !d.shouldRetry(resp.StatusCode) || attempt == d.maxRetries {
+		if !rewindable || !d.shouldRetry(resp.StatusCode) || attempt == d.maxRetries {
 			return resp, nil
 		}
+		wait := backoff(attempt, d.baseBackoff, d.maxBackoff)
+		if ra := RetryAfter(resp.Header, time.Now()); ra > 0 {
+			if ra > d.maxWait {
+				// The server asks for longer than we are willing to hold the call.
+				return resp, nil
+			}
+			wait = ra
+		}
 		// Drain and close the body before retrying to reuse connections.
 		io.Copy(io.Discard, resp.Body)
 		resp.Body.Close()
-		d.sleep(backoff(attempt, d.baseBackoff, d.maxBackoff))
+		if err := d.wait(ctx, RetryInfo{Request: req, Attempt: attempt + 1, Delay: wait, StatusCode: resp.StatusCode}); err != nil {
+			return nil, err
+		}
 	}
 
 	// Should not reach here.
 	return resp, lastErr
 }
 
-func (d *Doer) sleep(dur time.Duration) {
-	// Sleep respecting context is handled at a higher level; here we simply wait.
-	time.Sleep(dur)
+// wait reports the retry to OnRetry and sleeps for info.Delay, returning early
+// with the context error when ctx is done.
+func (d *Doer) wait(ctx context.Context, info RetryInfo) error {
+	if d.onRetry != nil {
+		d.onRetry(info)
+	}
+	t := time.NewTimer(info.Delay)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
 }
 
 func isTempOrTimeout(err error) bool {
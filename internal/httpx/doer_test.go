@@ -162,7 +162,7 @@ func TestDo_MaxRetriesAndReturnsLastResponse(t *testing.T) {
 	}
 }
 
-func TestDo_ErrorOnNonRewindableBodyWhenRetrying(t *testing.T) {
+func TestDo_ReturnsResponseOnNonRewindableBody(t *testing.T) {
 	var calls int
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		calls++
@@ -172,22 +172,33 @@ func TestDo_ErrorOnNonRewindableBodyWhenRetrying(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	doer := tinyBackoffDoer(ts.Client().Transport, 1)
+	var retries int
+	doer := New(Options{
+		Transport:   ts.Client().Transport,
+		MaxRetries:  1,
+		BaseBackoff: time.Second,
+		OnRetry:     func(RetryInfo) { retries++ },
+	})
 
-	// Body SEM GetBody => não dá para rebobinar; deve falhar ao tentar retry.
+	// Body SEM GetBody => não dá para rebobinar; a resposta é devolvida sem retry nem espera.
 	body := io.NopCloser(bytes.NewBufferString(`payload`))
 	req, _ := http.NewRequest(http.MethodPost, ts.URL, body)
 	// (req.GetBody == nil)
 
-	_, err := doer.Do(context.Background(), req)
-	if err == nil {
-		t.Fatalf("expected error due to non-rewindable body, got nil")
+	start := time.Now()
+	resp, err := doer.Do(context.Background(), req)
+	if err != nil {
+		t.Fatalf("expected the 500 response, got %v", err)
 	}
-	if !strings.Contains(err.Error(), "not rewindable") {
-		t.Fatalf("unexpected error: %v", err)
+	defer resp.Body.Close()
+	if b, _ := io.ReadAll(resp.Body); resp.StatusCode != 500 || string(b) != "oops" {
+		t.Fatalf("unexpected response %d %q", resp.StatusCode, b)
 	}
-	if calls != 1 {
-		t.Fatalf("should fail before 2nd attempt, calls=%d", calls)
+	if calls != 1 || retries != 0 {
+		t.Fatalf("should not retry, calls=%d retries=%d", calls, retries)
+	}
+	if el := time.Since(start); el > 500*time.Millisecond {
+		t.Fatalf("waited a backoff for a request it cannot resend: %v", el)
 	}
 }
 
@@ -0,0 +1,61 @@
+package httpx
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryAfter returns how long the server asked clients to wait, based on the
+// standard Retry-After header (seconds or HTTP date) and on Meta's
+// estimated_time_to_regain_access (minutes) found in X-Business-Use-Case-Usage
+// or X-App-Usage. The longest hint wins; zero means no hint.
+func RetryAfter(h http.Header, now time.Time) time.Duration {
+	var d time.Duration
+	if v := strings.TrimSpace(h.Get("Retry-After")); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+			d = time.Duration(secs) * time.Second
+		} else if t, err := http.ParseTime(v); err == nil && t.After(now) {
+			d = t.Sub(now)
+		}
+	}
+	for _, name := range []string{"X-Business-Use-Case-Usage", "X-App-Usage"} {
+		if m := regainAccessMinutes(h.Get(name)); time.Duration(m)*time.Minute > d {
+			d = time.Duration(m) * time.Minute
+		}
+	}
+	return d
+}
+
+// regainAccessMinutes extracts the largest estimated_time_to_regain_access from
+// a usage header. X-Business-Use-Case-Usage maps business IDs to lists of usage
+// objects; X-App-Usage is a single object.
+func regainAccessMinutes(v string) int {
+	if v == "" {
+		return 0
+	}
+	type usage struct {
+		EstimatedTimeToRegainAccess int `json:"estimated_time_to_regain_access"`
+	}
+
+	var single usage
+	if err := json.Unmarshal([]byte(v), &single); err == nil && single.EstimatedTimeToRegainAccess > 0 {
+		return single.EstimatedTimeToRegainAccess
+	}
+
+	var byBusiness map[string][]usage
+	if err := json.Unmarshal([]byte(v), &byBusiness); err != nil {
+		return 0
+	}
+	max := 0
+	for _, list := range byBusiness {
+		for _, u := range list {
+			if u.EstimatedTimeToRegainAccess > max {
+				max = u.EstimatedTimeToRegainAccess
+			}
+		}
+	}
+	return max
+}
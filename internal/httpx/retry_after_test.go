@@ -0,0 +1,154 @@
+package httpx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name string
+		h    http.Header
+		want time.Duration
+	}{
+		{"none", http.Header{}, 0},
+		{"seconds", http.Header{"Retry-After": {"7"}}, 7 * time.Second},
+		{"http date", http.Header{"Retry-After": {now.Add(90 * time.Second).Format(http.TimeFormat)}}, 90 * time.Second},
+		{"date in the past", http.Header{"Retry-After": {now.Add(-time.Minute).Format(http.TimeFormat)}}, 0},
+		{"garbage", http.Header{"Retry-After": {"soon"}}, 0},
+		{"business use case", http.Header{"X-Business-Use-Case-Usage": {
+			`{"123":[{"type":"whatsapp_business_management","call_count":100,"estimated_time_to_regain_access":0},` +
+				`{"type":"messaging","call_count":100,"estimated_time_to_regain_access":2}]}`,
+		}}, 2 * time.Minute},
+		{"app usage", http.Header{"X-App-Usage": {`{"call_count":100,"total_time":20,"estimated_time_to_regain_access":1}`}}, time.Minute},
+		{"longest wins", http.Header{
+			"Retry-After": {"5"},
+			"X-App-Usage": {`{"estimated_time_to_regain_access":3}`},
+		}, 3 * time.Minute},
+		{"usage without hint", http.Header{"X-App-Usage": {`{"call_count":10}`}}, 0},
+		{"malformed usage", http.Header{"X-Business-Use-Case-Usage": {`{`}}, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := RetryAfter(tc.h, now); got != tc.want {
+				t.Fatalf("RetryAfter = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDo_HonorsRetryAfter(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	var infos []RetryInfo
+	d := New(Options{
+		Transport:   ts.Client().Transport,
+		MaxRetries:  2,
+		BaseBackoff: time.Millisecond,
+		MaxBackoff:  2 * time.Millisecond,
+		OnRetry:     func(ri RetryInfo) { infos = append(infos, ri) },
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, ts.URL, nil)
+	start := time.Now()
+	resp, err := d.Do(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Do() error: %v", err)
+	}
+	resp.Body.Close()
+
+	// O atraso pedido pelo servidor (1s) prevalece sobre o backoff de 1ms.
+	if el := time.Since(start); el < time.Second {
+		t.Fatalf("expected to wait for Retry-After, waited %v", el)
+	}
+	if len(infos) != 1 {
+		t.Fatalf("expected 1 OnRetry call, got %d", len(infos))
+	}
+	if ri := infos[0]; ri.Attempt != 1 || ri.Delay != time.Second || ri.StatusCode != http.StatusTooManyRequests || ri.Err != nil || ri.Request == nil {
+		t.Fatalf("unexpected RetryInfo: %+v", ri)
+	}
+}
+
+func TestDo_RetryAfterAboveCapReturnsResponse(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("X-Business-Use-Case-Usage", `{"1":[{"estimated_time_to_regain_access":10}]}`)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+
+	d := New(Options{Transport: ts.Client().Transport, MaxRetries: 3, MaxRetryAfter: time.Second})
+	req, _ := http.NewRequest(http.MethodGet, ts.URL, nil)
+	resp, err := d.Do(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Do() error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTooManyRequests || calls != 1 {
+		t.Fatalf("expected the 429 without retrying, got status=%d calls=%d", resp.StatusCode, calls)
+	}
+}
+
+func TestDo_ContextCanceledDuringBackoff(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	d := New(Options{
+		Transport:   ts.Client().Transport,
+		MaxRetries:  3,
+		BaseBackoff: time.Minute,
+		MaxBackoff:  time.Minute,
+		// Cancela assim que o Doer decide esperar.
+		OnRetry: func(RetryInfo) { cancel() },
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, ts.URL, nil)
+	start := time.Now()
+	_, err := d.Do(ctx, req)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if el := time.Since(start); el > 5*time.Second {
+		t.Fatalf("backoff ignored cancellation, took %v", el)
+	}
+}
+
+func TestDo_OnRetryNetworkError(t *testing.T) {
+	rt := &flipFlopRT{}
+	var got RetryInfo
+	d := New(Options{
+		Transport:   rt,
+		MaxRetries:  2,
+		BaseBackoff: time.Millisecond,
+		MaxBackoff:  2 * time.Millisecond,
+		OnRetry:     func(ri RetryInfo) { got = ri },
+	})
+	req, _ := http.NewRequest(http.MethodGet, "http://example.local/x", nil)
+	resp, err := d.Do(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Do() error: %v", err)
+	}
+	resp.Body.Close()
+	if got.Attempt != 1 || got.Err == nil || got.StatusCode != 0 {
+		t.Fatalf("unexpected RetryInfo: %+v", got)
+	}
+}
@@ -0,0 +1,23 @@
+package httpx
+
+import (
+	"context"
+	"time"
+)
+
+type timeoutKey struct{}
+
+// WithTimeout makes requests sent with ctx use d as their per-request timeout
+// in place of the client-wide one, e.g. for media downloads that legitimately
+// take longer than an API call. A sooner ctx deadline still wins.
+func WithTimeout(ctx context.Context, d time.Duration) context.Context {
+	return context.WithValue(ctx, timeoutKey{}, d)
+}
+
+// Timeout returns the per-request timeout set by WithTimeout, or def.
+func Timeout(ctx context.Context, def time.Duration) time.Duration {
+	if d, ok := ctx.Value(timeoutKey{}).(time.Duration); ok {
+		return d
+	}
+	return def
+}
@@ -0,0 +1,161 @@
+package errorsx
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// CodeError names a Graph / WhatsApp Cloud API error code. The catalog below
+// holds one sentinel per well-known code; a *GraphError matches a sentinel
+// with errors.Is when its Detail.Code is equal:
+//
+//	if errors.Is(err, errorsx.ErrReengagementWindow) { /* send a template */ }
+type CodeError struct {
+	Code  int
+	Title string
+}
+
+// Error implements the error interface.
+func (e *CodeError) Error() string {
+	return fmt.Sprintf("graph code %d: %s", e.Code, e.Title)
+}
+
+// Catalog of Graph and WhatsApp Cloud API error codes.
+var (
+	// Generic Graph errors.
+	ErrAPIUnknown         = register(1, "API unknown")
+	ErrAPIService         = register(2, "API service")
+	ErrAPITooManyCalls    = register(4, "API too many calls")
+	ErrPermissionDenied   = register(10, "permission denied")
+	ErrInvalidParameter   = register(100, "invalid parameter")
+	ErrAccessTokenExpired = register(190, "access token expired or invalid")
+	ErrPolicyBlock        = register(368, "temporarily blocked for policy violations")
+	ErrAccountRateLimit   = register(80007, "WhatsApp Business Account rate limit hit")
+
+	// Throughput and messaging.
+	ErrThroughputLimit       = register(130429, "cloud API throughput limit hit")
+	ErrSomethingWentWrong    = register(131000, "something went wrong")
+	ErrAccessDenied          = register(131005, "access denied")
+	ErrMissingParameter      = register(131008, "required parameter is missing")
+	ErrInvalidParameterValue = register(131009, "parameter value is not valid")
+	ErrServiceUnavailable    = register(131016, "service unavailable")
+	ErrRecipientIsSender     = register(131021, "recipient cannot be sender")
+	ErrUndeliverable         = register(131026, "message undeliverable")
+	ErrRecipientNotAllowed   = register(131030, "recipient phone number not in allowed list")
+	ErrAccountLocked         = register(131031, "business account locked")
+	ErrPaymentIssue          = register(131042, "business eligibility payment issue")
+	ErrReengagementWindow    = register(131047, "more than 24 hours since the customer last replied")
+	ErrSpamRateLimit         = register(131048, "spam rate limit hit")
+	ErrEcosystemEngagement   = register(131049, "message not delivered to maintain healthy ecosystem engagement")
+	ErrUnsupportedMessage    = register(131051, "unsupported message type")
+	ErrMediaDownload         = register(131052, "media download error")
+	ErrMediaUpload           = register(131053, "media upload error")
+	ErrPairRateLimit         = register(131056, "too many messages to the same recipient")
+	ErrMaintenanceMode       = register(131057, "account in maintenance mode")
+
+	// Templates.
+	ErrTemplateParamCount  = register(132000, "template parameter count mismatch")
+	ErrTemplateNotFound    = register(132001, "template does not exist")
+	ErrTemplateTextTooLong = register(132005, "template hydrated text too long")
+	ErrTemplatePolicy      = register(132007, "template format character policy violated")
+	ErrTemplateParamFormat = register(132012, "template parameter format mismatch")
+	ErrTemplatePaused      = register(132015, "template is paused")
+	ErrTemplateDisabled    = register(132016, "template is disabled")
+
+	// Registration.
+	ErrPhoneNotRegistered = register(133010, "phone number not registered")
+)
+
+var catalog = map[int]*CodeError{}
+
+func register(code int, title string) *CodeError {
+	e := &CodeError{Code: code, Title: title}
+	catalog[code] = e
+	return e
+}
+
+// LookupCode returns the catalog sentinel for code, or nil when unknown. Useful
+// for codes that do not come wrapped in a GraphError, such as webhook statuses.
+func LookupCode(code int) *CodeError { return catalog[code] }
+
+// Is lets errors.Is match a GraphError against catalog sentinels by code.
+func (e *GraphError) Is(target error) bool {
+	ce, ok := target.(*CodeError)
+	return ok && e != nil && ce.Code == e.Detail.Code
+}
+
+// CodeOf returns the Graph error code carried by err, or 0 when err does not
+// wrap a decoded *GraphError.
+func CodeOf(err error) int {
+	var ge *GraphError
+	if errors.As(err, &ge) {
+		return ge.Detail.Code
+	}
+	return 0
+}
+
+var (
+	rateLimitCodes = codeSet(ErrAPITooManyCalls, ErrAccountRateLimit, ErrThroughputLimit, ErrSpamRateLimit, ErrPairRateLimit)
+	authCodes      = codeSet(ErrPermissionDenied, ErrAccessTokenExpired, ErrAccessDenied)
+	templateCodes  = codeSet(ErrTemplateParamCount, ErrTemplateNotFound, ErrTemplateTextTooLong, ErrTemplatePolicy,
+		ErrTemplateParamFormat, ErrTemplatePaused, ErrTemplateDisabled)
+	recipientCodes = codeSet(ErrRecipientIsSender, ErrUndeliverable, ErrRecipientNotAllowed, ErrReengagementWindow,
+		ErrEcosystemEngagement)
+	// transientCodes may succeed when the same request is retried later.
+	transientCodes = codeSet(ErrAPIUnknown, ErrAPIService, ErrAPITooManyCalls, ErrAccountRateLimit, ErrThroughputLimit,
+		ErrSomethingWentWrong, ErrServiceUnavailable, ErrPairRateLimit)
+)
+
+func codeSet(errs ...*CodeError) map[int]bool {
+	m := make(map[int]bool, len(errs))
+	for _, e := range errs {
+		m[e.Code] = true
+	}
+	return m
+}
+
+// IsRateLimit reports whether err is a throttling error: HTTP 429 or one of the
+// rate limit codes (4, 80007, 130429, 131048, 131056).
+func IsRateLimit(err error) bool {
+	return rateLimitCodes[CodeOf(err)] || statusOf(err) == http.StatusTooManyRequests
+}
+
+// IsAuth reports whether err is an authentication or permission failure:
+// HTTP 401 or codes 10, 190 (token) and 131005 (also the 200-299 permission range).
+func IsAuth(err error) bool {
+	code := CodeOf(err)
+	return authCodes[code] || (code >= 200 && code <= 299) || statusOf(err) == http.StatusUnauthorized
+}
+
+// IsTemplateError reports whether err is caused by the message template (132xxx).
+func IsTemplateError(err error) bool { return templateCodes[CodeOf(err)] }
+
+// IsRecipientError reports whether err is caused by the recipient, e.g. an
+// undeliverable number or a closed customer service window.
+func IsRecipientError(err error) bool { return recipientCodes[CodeOf(err)] }
+
+// IsPermanent reports whether sending the same request again cannot succeed:
+// validation failures, and client errors that are neither transient, rate
+// limits nor auth failures (a refreshed token may fix the latter).
+func IsPermanent(err error) bool {
+	var ve *ValidationError
+	if errors.As(err, &ve) {
+		return true
+	}
+	if code := CodeOf(err); code != 0 {
+		return !transientCodes[code] && !IsRateLimit(err) && !IsAuth(err)
+	}
+	st := statusOf(err)
+	return st >= 400 && st < 500 && st != http.StatusTooManyRequests && st != http.StatusRequestTimeout &&
+		st != http.StatusUnauthorized
+}
+
+// statusOf returns the HTTP status carried by err, or 0.
+func statusOf(err error) int {
+	var he *HTTPError
+	if errors.As(err, &he) {
+		return he.StatusCode
+	}
+	return 0
+}
@@ -0,0 +1,96 @@
+package errorsx
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func graphErr(status, code int) *GraphError {
+	req, _ := http.NewRequest(http.MethodPost, "http://x", nil)
+	resp := &http.Response{StatusCode: status, Status: http.StatusText(status), Header: http.Header{}, Request: req}
+	body := []byte(fmt.Sprintf(`{"error":{"message":"m","type":"OAuthException","code":%d}}`, code))
+	return TryParseGraphError(resp, body)
+}
+
+func TestGraphErrorIsSentinel(t *testing.T) {
+	err := fmt.Errorf("send: %w", graphErr(400, 131047))
+	if !errors.Is(err, ErrReengagementWindow) {
+		t.Fatalf("expected errors.Is to match ErrReengagementWindow")
+	}
+	if errors.Is(err, ErrUndeliverable) {
+		t.Fatalf("did not expect match on a different code")
+	}
+	// Wrapped HTTPError is still reachable.
+	var he *HTTPError
+	if !errors.As(err, &he) || he.StatusCode != 400 {
+		t.Fatalf("HTTPError not reachable: %v", err)
+	}
+	if CodeOf(err) != 131047 || CodeOf(errors.New("x")) != 0 {
+		t.Fatalf("unexpected CodeOf")
+	}
+	if LookupCode(132001) != ErrTemplateNotFound || LookupCode(42) != nil {
+		t.Fatalf("unexpected LookupCode")
+	}
+	if !strings.Contains(ErrPolicyBlock.Error(), "368") {
+		t.Fatalf("unexpected sentinel message: %s", ErrPolicyBlock)
+	}
+}
+
+func TestErrorCategories(t *testing.T) {
+	cases := []struct {
+		name                                         string
+		err                                          error
+		rate, auth, template, recipient, perm, retry bool
+	}{
+		{"throughput", graphErr(400, 130429), true, false, false, false, false, true},
+		{"pair rate limit", graphErr(400, 131056), true, false, false, false, false, true},
+		{"spam rate limit", graphErr(400, 131048), true, false, false, false, false, false},
+		{"http 429", &HTTPError{StatusCode: 429}, true, false, false, false, false, true},
+		{"token", graphErr(401, 190), false, true, false, false, false, false},
+		{"permission range", graphErr(403, 200), false, true, false, false, false, false},
+		{"http 401", &HTTPError{StatusCode: 401}, false, true, false, false, false, false},
+		{"template missing", graphErr(404, 132001), false, false, true, false, true, false},
+		{"reengagement", graphErr(400, 131047), false, false, false, true, true, false},
+		{"undeliverable", graphErr(400, 131026), false, false, false, true, true, false},
+		{"policy block", graphErr(400, 368), false, false, false, false, true, false},
+		{"transient 131000", graphErr(500, 131000), false, false, false, false, false, true},
+		{"validation", &ValidationError{Op: "x"}, false, false, false, false, true, false},
+		{"http 400", &HTTPError{StatusCode: 400}, false, false, false, false, true, false},
+		{"http 503", &HTTPError{StatusCode: 503}, false, false, false, false, false, true},
+		{"plain", errors.New("x"), false, false, false, false, false, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := [...]bool{IsRateLimit(tc.err), IsAuth(tc.err), IsTemplateError(tc.err), IsRecipientError(tc.err), IsPermanent(tc.err), IsRetryable(tc.err)}
+			want := [...]bool{tc.rate, tc.auth, tc.template, tc.recipient, tc.perm, tc.retry}
+			if got != want {
+				t.Fatalf("rate/auth/template/recipient/permanent/retryable = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestGraphErrorDetailUserFields(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodPost, "http://x", nil)
+	resp := &http.Response{StatusCode: 400, Status: "400", Header: http.Header{}, Request: req}
+	body := []byte(`{"error":{"message":"(#131009) Parameter value is not valid","type":"OAuthException","code":131009,` +
+		`"error_user_title":"Invalid parameter","error_user_msg":"Check the phone number",` +
+		`"error_data":{"messaging_product":"whatsapp","details":"Invalid 'to' value"},"fbtrace_id":"t"}}`)
+	ge := TryParseGraphError(resp, body)
+	d := ge.Detail
+	if d.ErrorUserTitle != "Invalid parameter" || d.ErrorUserMsg != "Check the phone number" {
+		t.Fatalf("user fields not decoded: %+v", d)
+	}
+	if d.ErrorData == nil || d.ErrorData.Details != "Invalid 'to' value" || d.ErrorData.MessagingProduct != "whatsapp" {
+		t.Fatalf("error_data not decoded: %+v", d.ErrorData)
+	}
+	if !strings.Contains(ge.Error(), "Invalid 'to' value") {
+		t.Fatalf("details missing from error string: %s", ge.Error())
+	}
+	if !errors.Is(ge, ErrInvalidParameterValue) {
+		t.Fatalf("expected ErrInvalidParameterValue")
+	}
+}
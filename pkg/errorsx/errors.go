@@ -37,8 +37,12 @@ func (e *HTTPError) Error() string {
 }
 
 // IsRetryable reports whether the given error suggests a transient condition
-// where a retry (with backoff) might succeed.
+// where a retry (with backoff) might succeed: transient Graph codes (see
+// IsRateLimit and the catalog in codes.go), HTTP 429 and most 5xx.
 func IsRetryable(err error) bool {
+	if transientCodes[CodeOf(err)] {
+		return true
+	}
 	var he *HTTPError
 	if errors.As(err, &he) {
 		// Retry 429 and 5xx, except for 501/505 which usually indicate permanent issues.
@@ -73,11 +77,21 @@ func NewHTTPErrorFromResponse(resp *http.Response, body []byte) *HTTPError {
 // GraphErrorDetail mirrors the canonical Facebook Graph error payload shape.
 // See Meta Graph documentation for the authoritative schema.
 type GraphErrorDetail struct {
-	Message      string `json:"message"`
-	Type         string `json:"type"`
-	Code         int    `json:"code"`
-	ErrorSubcode int    `json:"error_subcode,omitempty"`
-	FBTraceID    string `json:"fbtrace_id,omitempty"`
+	Message        string          `json:"message"`
+	Type           string          `json:"type"`
+	Code           int             `json:"code"`
+	ErrorSubcode   int             `json:"error_subcode,omitempty"`
+	ErrorUserTitle string          `json:"error_user_title,omitempty"`
+	ErrorUserMsg   string          `json:"error_user_msg,omitempty"`
+	ErrorData      *GraphErrorData `json:"error_data,omitempty"`
+	FBTraceID      string          `json:"fbtrace_id,omitempty"`
+}
+
+// GraphErrorData holds the WhatsApp-specific error_data object; Details usually
+// explains which parameter or condition caused the failure.
+type GraphErrorData struct {
+	MessagingProduct string `json:"messaging_product,omitempty"`
+	Details          string `json:"details,omitempty"`
 }
 
 // GraphError wraps an HTTPError with a decoded Graph error payload, when available.
@@ -93,6 +107,9 @@ func (e *GraphError) Error() string {
 	if e == nil {
 		return "<nil>"
 	}
+	if e.Detail.Message != "" && e.Detail.ErrorData != nil && e.Detail.ErrorData.Details != "" {
+		return fmt.Sprintf("graph error: %s: %s (type=%s code=%d subcode=%d) — %s", e.Detail.Message, e.Detail.ErrorData.Details, e.Detail.Type, e.Detail.Code, e.Detail.ErrorSubcode, e.HTTP.Error())
+	}
 	if e.Detail.Message != "" {
 		return fmt.Sprintf("graph error: %s (type=%s code=%d subcode=%d) — %s", e.Detail.Message, e.Detail.Type, e.Detail.Code, e.Detail.ErrorSubcode, e.HTTP.Error())
 	}
@@ -141,3 +158,21 @@ func (e *ValidationError) Error() string {
 	}
 	return fmt.Sprintf("validation error: op=%s: %s", e.Op, e.Reason)
 }
+
+// IntegrityError reports downloaded content that does not match what the API
+// advertised (file_size, sha256) or that exceeds the caller's size limit
+// (max_size). Data already written to the destination must be discarded.
+type IntegrityError struct {
+	Op       string // High-level operation name (e.g., "DownloadTo")
+	Field    string // "file_size", "sha256" or "max_size"
+	Expected string // Advertised value or limit
+	Actual   string // Observed value
+}
+
+// Error implements the error interface.
+func (e *IntegrityError) Error() string {
+	if e == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("integrity error: op=%s field=%s: expected %s, got %s", e.Op, e.Field, e.Expected, e.Actual)
+}
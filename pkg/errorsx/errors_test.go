@@ -101,3 +101,14 @@ func TestValidationErrorError(t *testing.T) {
 		t.Fatalf("did not expect field info: %s", v.Error())
 	}
 }
+
+func TestIntegrityErrorError(t *testing.T) {
+	var ie *IntegrityError
+	if ie.Error() != "<nil>" {
+		t.Fatalf("nil receiver error != <nil>")
+	}
+	ie = &IntegrityError{Op: "DownloadTo", Field: "sha256", Expected: "aa", Actual: "bb"}
+	if got := ie.Error(); !strings.Contains(got, "field=sha256") || !strings.Contains(got, "expected aa, got bb") {
+		t.Fatalf("unexpected message: %s", got)
+	}
+}
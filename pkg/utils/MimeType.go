@@ -1,32 +1,71 @@
 package utils
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/textproto"
 	"os"
 	"path/filepath"
-	"slices"
+	"strings"
 )
 
+// officeMimeTypes covers the OOXML formats http.DetectContentType reports as zip.
+var officeMimeTypes = map[string]string{
+	".docx": "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	".xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	".pptx": "application/vnd.openxmlformats-officedocument.presentationml.presentation",
+}
+
+// GetMimeTypeHeader builds the multipart header of the "file" part for f. The
+// file offset is restored after sniffing, so no content is lost.
+//
+// Deprecated: use DetectMimeType and FilePartHeader.
 func GetMimeTypeHeader(f *os.File) textproto.MIMEHeader {
-	ext := filepath.Ext(f.Name())
-	mimeType := ""
-	if slices.Contains([]string{".xlsx", ".docx", ".pptx"}, ext) {
-		mimeType = map[string]string{
-			".docx": "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
-			".xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
-			".pptx": "application/vnd.openxmlformats-officedocument.presentationml.presentation",
-		}[ext]
-	} else {
-		head := make([]byte, 512)
-		n, _ := f.Read(head)
-		mimeType = http.DetectContentType(head[:n])
-	}
+	mimeType, _, _ := DetectMimeType(f.Name(), f)
+	return FilePartHeader(f.Name(), mimeType)
+}
 
+// FilePartHeader builds the multipart header of the "file" part.
+func FilePartHeader(filename, mimeType string) textproto.MIMEHeader {
+	name := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(filepath.Base(filename))
 	h := make(textproto.MIMEHeader)
-	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, filepath.Base(f.Name())))
+	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, name))
 	h.Set("Content-Type", mimeType)
-
 	return h
 }
+
+// DetectMimeType infers the MIME type of content named filename and read from r.
+// Known Office extensions map directly; otherwise the first 512 bytes are
+// sniffed. The returned reader yields the complete content: seekable readers
+// are rewound and are returned as-is, others are wrapped in a buffered reader.
+func DetectMimeType(filename string, r io.Reader) (string, io.Reader, error) {
+	if mt, ok := officeMimeTypes[strings.ToLower(filepath.Ext(filename))]; ok {
+		return mt, r, nil
+	}
+
+	if rs, ok := r.(io.ReadSeeker); ok {
+		pos, err := rs.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return "", r, fmt.Errorf("seek: %w", err)
+		}
+		head := make([]byte, 512)
+		n, err := io.ReadFull(rs, head)
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return "", r, fmt.Errorf("sniff: %w", err)
+		}
+		if _, err := rs.Seek(pos, io.SeekStart); err != nil {
+			return "", r, fmt.Errorf("seek: %w", err)
+		}
+		return http.DetectContentType(head[:n]), rs, nil
+	}
+
+	br := bufio.NewReaderSize(r, 512)
+	head, err := br.Peek(512)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return "", br, fmt.Errorf("sniff: %w", err)
+	}
+	return http.DetectContentType(head), br, nil
+}
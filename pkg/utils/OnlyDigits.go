@@ -0,0 +1,12 @@
+package utils
+
+import "strings"
+
+func OnlyDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < '0' || r > '9' {
+			return -1
+		}
+		return r
+	}, s)
+}
@@ -3,6 +3,7 @@ package whatsapp
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 type Client struct {
 	version       string
 	wabaID        string
+	appID         string
 	phoneNumberID string
 
 	httpDoer      ports.HTTPDoer
@@ -28,16 +30,19 @@ type Client struct {
 	Registration *services.RegistrationService
 	Webhook      *services.WebhookService
 	Media        *services.MediaService
+	Templates    *services.TemplatesService
+	Uploads      *services.UploadsService
 
 	baseURL  string
 	timeout  time.Duration
 	retryMax int
 	uaExtra  string
+	refresh  refreshGroup
 }
 
 // NewClient validates options, applies defaults and returns a ready-to-use Client.
 // If no HTTPDoer is provided, a default httpx.Doer is constructed using RetryMax
-// from Options and the default RoundTripper.
+// and OnRetry from Options and the default RoundTripper.
 func NewClient(o Options) (*Client, error) {
 	if err := o.Validate(); err != nil {
 		return nil, err
@@ -46,18 +51,14 @@ func NewClient(o Options) (*Client, error) {
 
 	var doer ports.HTTPDoer = o.HTTPDoer
 	if doer == nil {
-		doer = httpx.New(httpx.Options{MaxRetries: o.RetryMax})
+		doer = httpx.New(httpx.Options{MaxRetries: o.RetryMax, OnRetry: o.OnRetry})
 	}
 
-	phoneAPI := graph.NewPhoneAPI(doer, o.TokenProvider, o.Version, o.WABAID, o.BaseURL)
-	regAPI := graph.NewRegistrationAPI(doer, o.TokenProvider, o.Version, o.PhoneNumberID, o.BaseURL)
-
 	c := &Client{
-		Phone:         services.NewPhoneService(phoneAPI),
-		Registration:  services.NewRegistrationService(regAPI),
 		Webhook:       services.NewWebhookService(o.SecretsProvider),
 		version:       o.Version,
 		wabaID:        o.WABAID,
+		appID:         o.AppID,
 		phoneNumberID: o.PhoneNumberID,
 		httpDoer:      doer,
 		tokenProvider: o.TokenProvider,
@@ -67,25 +68,37 @@ func NewClient(o Options) (*Client, error) {
 		retryMax:      o.RetryMax,
 		uaExtra:       o.UserAgent,
 	}
+	// The Graph adapters execute through c so that they share the timeout,
+	// User-Agent and token refresh handling of the other services.
+	c.Phone = services.NewPhoneService(graph.NewPhoneAPI(c, o.TokenProvider, o.Version, o.WABAID, o.BaseURL))
+	c.Registration = services.NewRegistrationService(graph.NewRegistrationAPI(c, o.TokenProvider, o.Version, o.PhoneNumberID, o.BaseURL))
 	c.Messages = services.NewMessagesService(c)
-	c.Media = services.NewMediaService(c)
+	c.Media = services.NewMediaService(c).WithTimeouts(o.MediaURLTimeout, o.MediaDownloadTimeout).
+		WithUploadTimeout(o.MediaUploadTimeout)
+	c.Templates = services.NewTemplatesService(c)
+	c.Uploads = services.NewUploadsService(c)
 	return c, nil
 }
 
 // do constructs and executes an HTTP request using the configured HTTPDoer.
 // It injects Authorization and User-Agent headers, applies timeout, and returns
-// the raw *http.Response for the caller to decode.
+// the raw *http.Response for the caller to decode. When the token is rejected
+// (401 or Graph code 190) it calls TokenProvider.Refresh and replays the
+// request once, provided the body can be rewound via GetBody.
 func (c *Client) do(ctx context.Context, req *http.Request) (*http.Response, error) {
-	// Apply per-request timeout via context.
-	var cancel context.CancelFunc
-	if c.timeout > 0 {
-		ctx, cancel = context.WithTimeout(ctx, c.timeout)
-		defer cancel()
+	// Apply the per-request timeout via context; a sooner deadline of the
+	// caller's wins. MediaService replaces it with its longer media timeouts
+	// (see httpx.WithTimeout). It covers reading the body too, so it is
+	// released when the caller closes resp.Body.
+	cancel := context.CancelFunc(func() {})
+	if timeout := httpx.Timeout(ctx, c.timeout); timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
 	}
 
 	// Inject Authorization header.
 	token, err := c.tokenProvider.Token(ctx)
 	if err != nil {
+		cancel()
 		return nil, fmt.Errorf("get token: %w", err)
 	}
 	req.Header.Set("Authorization", "Bearer "+token)
@@ -99,16 +112,70 @@ func (c *Client) do(ctx context.Context, req *http.Request) (*http.Response, err
 
 	// Execute via injected transport.
 	resp, err := c.httpDoer.Do(ctx, req)
+	if err == nil && resp != nil && isAuthFailure(resp) && replayable(req) {
+		resp, err = c.refreshAndReplay(ctx, req, resp, token)
+	}
 	if err != nil {
+		cancel()
 		return nil, err
 	}
+	if resp.Body == nil {
+		cancel()
+		return resp, nil
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
 	return resp, nil
 }
 
+// refreshAndReplay refreshes the token after resp rejected it and sends req
+// once more. The refresh is shared with concurrent callers, and skipped when
+// another caller already rotated the token since req was sent.
+func (c *Client) refreshAndReplay(ctx context.Context, req *http.Request, resp *http.Response, used string) (*http.Response, error) {
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	err := c.refresh.do(ctx, func(ctx context.Context) error {
+		if cur, err := c.tokenProvider.Token(ctx); err == nil && cur != used {
+			return nil // already rotated by an earlier refresh
+		}
+		return c.tokenProvider.Refresh(ctx)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("refresh token: %w", err)
+	}
+	token, err := c.tokenProvider.Token(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get token: %w", err)
+	}
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("rewind body: %w", err)
+		}
+		req.Body = body
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return c.httpDoer.Do(ctx, req)
+}
+
+// cancelOnClose releases the request context once the body has been consumed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
 // Narrow getters used by services without leaking internal fields.
 
 func (c *Client) Version() string       { return c.version }
 func (c *Client) WABAID() string        { return c.wabaID }
+func (c *Client) AppID() string         { return c.appID }
 func (c *Client) PhoneNumberID() string { return c.phoneNumberID }
 func (c *Client) BaseURL() string {
 	if c.baseURL == "" {
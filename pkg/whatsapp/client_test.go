@@ -0,0 +1,139 @@
+package whatsapp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/errorsx"
+)
+
+func TestClient_AdaptersShareDoPipeline(t *testing.T) {
+	var gotUA, gotAuth string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUA, gotAuth = r.UserAgent(), r.Header.Get("Authorization")
+		w.Header().Set("X-Fb-Trace-Id", "trace-1")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"message":"Invalid parameter","type":"OAuthException","code":100}}`))
+	}))
+	defer ts.Close()
+
+	o := validOpts()
+	o.BaseURL = ts.URL
+	o.UserAgent = "my-app/1.0"
+	c, err := NewClient(o)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	checks := map[string]func() error{
+		"phone list": func() error { _, err := c.Phone.List(context.Background()); return err },
+		"deregister": func() error { _, err := c.Registration.Deregister(context.Background()); return err },
+		"media url":  func() error { _, err := c.Media.GetMediaURL(context.Background(), "m1"); return err },
+	}
+	for name, call := range checks {
+		err := call()
+		var ge *errorsx.GraphError
+		if !errors.As(err, &ge) || ge.HTTP.FBTraceID != "trace-1" || !errors.Is(err, errorsx.ErrInvalidParameter) {
+			t.Fatalf("%s: expected GraphError with trace id, got %v", name, err)
+		}
+		if gotUA != "ampere-whatsapp-sdk-go my-app/1.0" || gotAuth != "Bearer token" {
+			t.Fatalf("%s: UA=%q auth=%q", name, gotUA, gotAuth)
+		}
+	}
+}
+
+func TestClient_PhoneHonorsTimeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	}))
+	defer ts.Close()
+
+	o := validOpts()
+	o.BaseURL = ts.URL
+	o.Timeout = 20 * time.Millisecond
+	c, err := NewClient(o)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	start := time.Now()
+	if _, err := c.Phone.List(context.Background()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected DeadlineExceeded, got %v", err)
+	}
+	if el := time.Since(start); el > time.Second {
+		t.Fatalf("Options.Timeout not applied, took %v", el)
+	}
+}
+
+func TestClient_TimeoutWithCallerDeadline(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-time.After(100 * time.Millisecond):
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"url":"https://lookaside.local/m1","id":"m1"}`))
+	}))
+	defer ts.Close()
+
+	o := validOpts()
+	o.BaseURL = ts.URL
+	o.Timeout = 20 * time.Millisecond
+	c, err := NewClient(o)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	// A caller deadline further away than Timeout does not lift it.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	start := time.Now()
+	if _, err := c.Phone.List(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected DeadlineExceeded, got %v", err)
+	}
+	if el := time.Since(start); el > time.Second {
+		t.Fatalf("Options.Timeout not applied under a caller deadline, took %v", el)
+	}
+
+	// Media requests use the media timeouts instead.
+	if _, err := c.Media.GetMediaURL(ctx, "m1"); err != nil {
+		t.Fatalf("GetMediaURL cut short by Options.Timeout: %v", err)
+	}
+}
+
+func TestClient_UploadOutlastsTimeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/media") {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		io.Copy(io.Discard, r.Body)
+		time.Sleep(100 * time.Millisecond) // a slow uplink
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"media-1"}`))
+	}))
+	defer ts.Close()
+
+	o := validOpts()
+	o.BaseURL = ts.URL
+	o.Timeout = 20 * time.Millisecond
+	c, err := NewClient(o)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	up, err := c.Media.UploadMediaFrom(context.Background(), strings.NewReader("video bytes"), "clip.mp4", "video/mp4")
+	if err != nil {
+		t.Fatalf("upload cut short by Options.Timeout: %v", err)
+	}
+	if up.Id != "media-1" {
+		t.Fatalf("unexpected upload %+v", up)
+	}
+}
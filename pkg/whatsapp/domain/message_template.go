@@ -0,0 +1,392 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/errorsx"
+)
+
+// TemplateStatus is the review status of a message template.
+type TemplateStatus string
+
+const (
+	TemplateStatusApproved TemplateStatus = "APPROVED"
+	TemplateStatusPending  TemplateStatus = "PENDING"
+	TemplateStatusRejected TemplateStatus = "REJECTED"
+	TemplateStatusPaused   TemplateStatus = "PAUSED"
+	TemplateStatusDisabled TemplateStatus = "DISABLED"
+	TemplateStatusInAppeal TemplateStatus = "IN_APPEAL"
+)
+
+// TemplateCategory is the pricing/review category of a message template.
+type TemplateCategory string
+
+const (
+	TemplateCategoryMarketing      TemplateCategory = "MARKETING"
+	TemplateCategoryUtility        TemplateCategory = "UTILITY"
+	TemplateCategoryAuthentication TemplateCategory = "AUTHENTICATION"
+)
+
+// Parameter formats of a template definition. Positional is the API default.
+const (
+	TemplateParameterFormatPositional = "POSITIONAL"
+	TemplateParameterFormatNamed      = "NAMED"
+)
+
+// Component types and header formats of a template definition.
+const (
+	TemplateComponentHeader   = "HEADER"
+	TemplateComponentBody     = "BODY"
+	TemplateComponentFooter   = "FOOTER"
+	TemplateComponentButtons  = "BUTTONS"
+	TemplateComponentCarousel = "CAROUSEL"
+
+	TemplateComponentLimitedTimeOffer = "LIMITED_TIME_OFFER"
+
+	TemplateHeaderFormatText     = "TEXT"
+	TemplateHeaderFormatImage    = "IMAGE"
+	TemplateHeaderFormatVideo    = "VIDEO"
+	TemplateHeaderFormatDocument = "DOCUMENT"
+	TemplateHeaderFormatLocation = "LOCATION"
+)
+
+// Limits enforced by the API when creating templates.
+const (
+	MaxTemplateNameLength = 512
+	MaxTemplateHeaderText = 60
+	MaxTemplateBodyText   = 1024
+	MaxTemplateFooterText = 60
+	MaxTemplateButtons    = 10
+	MaxTemplateButtonText = 25
+)
+
+// MessageTemplate is a template definition as stored on the WhatsApp Business
+// Account (GET/POST /{WABA-ID}/message_templates).
+type MessageTemplate struct {
+	ID              string                     `json:"id,omitempty"`
+	Name            string                     `json:"name"`
+	Language        string                     `json:"language"`
+	Status          TemplateStatus             `json:"status,omitempty"`
+	Category        TemplateCategory           `json:"category"`
+	SubCategory     string                     `json:"sub_category,omitempty"`
+	ParameterFormat string                     `json:"parameter_format,omitempty"`
+	Components      []MessageTemplateComponent `json:"components"`
+	RejectedReason  string                     `json:"rejected_reason,omitempty"`
+	QualityScore    *MessageTemplateQuality    `json:"quality_score,omitempty"`
+
+	// AllowCategoryChange lets Meta re-categorize the template instead of rejecting it.
+	AllowCategoryChange *bool `json:"allow_category_change,omitempty"`
+}
+
+// MessageTemplateComponent is a HEADER, BODY, FOOTER, BUTTONS or CAROUSEL block.
+type MessageTemplateComponent struct {
+	Type    string                  `json:"type"`
+	Format  string                  `json:"format,omitempty"`
+	Text    string                  `json:"text,omitempty"`
+	Example *MessageTemplateExample `json:"example,omitempty"`
+	Buttons []MessageTemplateButton `json:"buttons,omitempty"`
+	Cards   []MessageTemplateCard   `json:"cards,omitempty"`
+
+	// LIMITED_TIME_OFFER components only.
+	LimitedTimeOffer *MessageTemplateLimitedTimeOffer `json:"limited_time_offer,omitempty"`
+
+	// Authentication templates only.
+	AddSecurityRecommendation *bool `json:"add_security_recommendation,omitempty"`
+	CodeExpirationMinutes     *int  `json:"code_expiration_minutes,omitempty"`
+}
+
+// MessageTemplateExample carries sample values required for review whenever a
+// component has variables.
+type MessageTemplateExample struct {
+	HeaderText            []string                    `json:"header_text,omitempty"`
+	HeaderHandle          []string                    `json:"header_handle,omitempty"`
+	BodyText              [][]string                  `json:"body_text,omitempty"`
+	HeaderTextNamedParams []MessageTemplateNamedParam `json:"header_text_named_params,omitempty"`
+	BodyTextNamedParams   []MessageTemplateNamedParam `json:"body_text_named_params,omitempty"`
+}
+
+type MessageTemplateNamedParam struct {
+	ParamName string `json:"param_name"`
+	Example   string `json:"example"`
+}
+
+// MessageTemplateButton is a button definition. Example is kept raw because its
+// shape depends on Type (an array for URL buttons, a string for COPY_CODE).
+type MessageTemplateButton struct {
+	Type           string          `json:"type"`
+	Text           string          `json:"text,omitempty"`
+	URL            string          `json:"url,omitempty"`
+	PhoneNumber    string          `json:"phone_number,omitempty"`
+	Example        json.RawMessage `json:"example,omitempty"`
+	OTPType        string          `json:"otp_type,omitempty"`
+	AutofillText   string          `json:"autofill_text,omitempty"`
+	PackageName    string          `json:"package_name,omitempty"`
+	SignatureHash  string          `json:"signature_hash,omitempty"`
+	FlowID         string          `json:"flow_id,omitempty"`
+	FlowAction     string          `json:"flow_action,omitempty"`
+	NavigateScreen string          `json:"navigate_screen,omitempty"`
+}
+
+// MessageTemplateCard is a single card of a carousel template.
+type MessageTemplateCard struct {
+	Components []MessageTemplateComponent `json:"components"`
+}
+
+type MessageTemplateLimitedTimeOffer struct {
+	Text          string `json:"text"`
+	HasExpiration bool   `json:"has_expiration"`
+}
+
+type MessageTemplateQuality struct {
+	Score string `json:"score"`
+}
+
+// MessageTemplateList is the envelope returned by GET /{WABA-ID}/message_templates.
+type MessageTemplateList struct {
+	Data   []MessageTemplate `json:"data"`
+	Paging *Paging           `json:"paging,omitempty"`
+}
+
+// MessageTemplateCreateResult is returned after creating a template.
+type MessageTemplateCreateResult struct {
+	ID       string           `json:"id"`
+	Status   TemplateStatus   `json:"status"`
+	Category TemplateCategory `json:"category"`
+}
+
+// MessageTemplateEdit carries the editable parts of an existing template.
+// Only non-zero fields are sent.
+type MessageTemplateEdit struct {
+	Category   TemplateCategory           `json:"category,omitempty"`
+	Components []MessageTemplateComponent `json:"components,omitempty"`
+}
+
+// TemplateListParams filters GET /{WABA-ID}/message_templates. Zero values are omitted.
+type TemplateListParams struct {
+	Status   TemplateStatus
+	Category TemplateCategory
+	Language string
+	Name     string
+	Limit    int
+	After    string
+	Before   string
+	Fields   []string
+}
+
+var (
+	templateNameRe        = regexp.MustCompile(`^[a-z0-9_]+$`)
+	templatePlaceholderRe = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_]+)\s*\}\}`)
+)
+
+// TemplatePlaceholders returns the variable names found in text, in order of
+// appearance, e.g. "Hi {{1}}, order {{2}}" -> ["1", "2"] and "{{first_name}}" -> ["first_name"].
+func TemplatePlaceholders(text string) []string {
+	matches := templatePlaceholderRe.FindAllStringSubmatch(text, -1)
+	out := make([]string, 0, len(matches))
+	for _, m := range matches {
+		out = append(out, m[1])
+	}
+	return out
+}
+
+// Validate checks a template definition before it is submitted for review.
+func (t *MessageTemplate) Validate() error {
+	const op = "ValidateMessageTemplate"
+	if t == nil {
+		return &errorsx.ValidationError{Op: op, Reason: "nil template"}
+	}
+	if t.Name == "" {
+		return &errorsx.ValidationError{Op: op, Field: "name", Reason: "empty"}
+	}
+	if len(t.Name) > MaxTemplateNameLength || !templateNameRe.MatchString(t.Name) {
+		return &errorsx.ValidationError{Op: op, Field: "name", Reason: "must be lowercase alphanumeric or underscore, up to 512 characters"}
+	}
+	if t.Language == "" {
+		return &errorsx.ValidationError{Op: op, Field: "language", Reason: "empty"}
+	}
+	switch t.Category {
+	case TemplateCategoryMarketing, TemplateCategoryUtility, TemplateCategoryAuthentication:
+	default:
+		return &errorsx.ValidationError{Op: op, Field: "category", Reason: "must be MARKETING, UTILITY or AUTHENTICATION"}
+	}
+	switch t.ParameterFormat {
+	case "", TemplateParameterFormatPositional, TemplateParameterFormatNamed:
+	default:
+		return &errorsx.ValidationError{Op: op, Field: "parameter_format", Reason: "must be POSITIONAL or NAMED"}
+	}
+	return validateTemplateComponents(op, "components", t.Components, t.ParameterFormat == TemplateParameterFormatNamed, t.Category == TemplateCategoryAuthentication)
+}
+
+func validateTemplateComponents(op, path string, components []MessageTemplateComponent, named, auth bool) error {
+	if len(components) == 0 {
+		return &errorsx.ValidationError{Op: op, Field: path, Reason: "empty components"}
+	}
+
+	seen := map[string]int{}
+	for i, c := range components {
+		field := fmt.Sprintf("%s[%d]", path, i)
+		kind := strings.ToUpper(c.Type)
+		seen[kind]++
+		if seen[kind] > 1 {
+			return &errorsx.ValidationError{Op: op, Field: field + ".type", Reason: "duplicated " + kind + " component"}
+		}
+
+		switch kind {
+		case TemplateComponentHeader:
+			if err := validateTemplateHeader(op, field, c, named); err != nil {
+				return err
+			}
+		case TemplateComponentBody:
+			if auth {
+				// Authentication bodies are fixed by Meta; text is not accepted.
+				continue
+			}
+			if c.Text == "" {
+				return &errorsx.ValidationError{Op: op, Field: field + ".text", Reason: "empty"}
+			}
+			if err := checkLen(op, field+".text", c.Text, MaxTemplateBodyText); err != nil {
+				return err
+			}
+			if err := validateTemplateVariables(op, field, c.Text, c.Example, named, false); err != nil {
+				return err
+			}
+		case TemplateComponentFooter:
+			if err := checkLen(op, field+".text", c.Text, MaxTemplateFooterText); err != nil {
+				return err
+			}
+			if len(TemplatePlaceholders(c.Text)) > 0 {
+				return &errorsx.ValidationError{Op: op, Field: field + ".text", Reason: "footer does not accept variables"}
+			}
+		case TemplateComponentButtons:
+			if err := validateTemplateButtons(op, field, c.Buttons); err != nil {
+				return err
+			}
+		case TemplateComponentCarousel:
+			if len(c.Cards) == 0 {
+				return &errorsx.ValidationError{Op: op, Field: field + ".cards", Reason: "empty cards"}
+			}
+			for j, card := range c.Cards {
+				if err := validateTemplateComponents(op, fmt.Sprintf("%s.cards[%d].components", field, j), card.Components, named, false); err != nil {
+					return err
+				}
+			}
+		case TemplateComponentLimitedTimeOffer:
+			if c.LimitedTimeOffer == nil || c.LimitedTimeOffer.Text == "" {
+				return &errorsx.ValidationError{Op: op, Field: field + ".limited_time_offer.text", Reason: "empty"}
+			}
+		default:
+			return &errorsx.ValidationError{Op: op, Field: field + ".type", Reason: "unknown component type " + c.Type}
+		}
+	}
+	if seen[TemplateComponentBody] == 0 {
+		return &errorsx.ValidationError{Op: op, Field: path, Reason: "a BODY component is required"}
+	}
+	return nil
+}
+
+func validateTemplateHeader(op, field string, c MessageTemplateComponent, named bool) error {
+	switch strings.ToUpper(c.Format) {
+	case TemplateHeaderFormatText:
+		if c.Text == "" {
+			return &errorsx.ValidationError{Op: op, Field: field + ".text", Reason: "empty"}
+		}
+		if err := checkLen(op, field+".text", c.Text, MaxTemplateHeaderText); err != nil {
+			return err
+		}
+		if n := len(TemplatePlaceholders(c.Text)); n > 1 {
+			return &errorsx.ValidationError{Op: op, Field: field + ".text", Reason: "header accepts at most one variable"}
+		}
+		return validateTemplateVariables(op, field, c.Text, c.Example, named, true)
+	case TemplateHeaderFormatImage, TemplateHeaderFormatVideo, TemplateHeaderFormatDocument:
+		if c.Example == nil || len(c.Example.HeaderHandle) == 0 {
+			return &errorsx.ValidationError{Op: op, Field: field + ".example.header_handle", Reason: "media headers require a sample handle"}
+		}
+		return nil
+	case TemplateHeaderFormatLocation:
+		return nil
+	default:
+		return &errorsx.ValidationError{Op: op, Field: field + ".format", Reason: "must be TEXT, IMAGE, VIDEO, DOCUMENT or LOCATION"}
+	}
+}
+
+// validateTemplateVariables checks that every variable in text is well-formed for
+// the parameter format and has a sample value.
+func validateTemplateVariables(op, field, text string, ex *MessageTemplateExample, named, header bool) error {
+	vars := TemplatePlaceholders(text)
+	if len(vars) == 0 {
+		return nil
+	}
+	if named {
+		var samples []MessageTemplateNamedParam
+		if ex != nil {
+			samples = ex.BodyTextNamedParams
+			if header {
+				samples = ex.HeaderTextNamedParams
+			}
+		}
+		have := make(map[string]bool, len(samples))
+		for _, s := range samples {
+			have[s.ParamName] = true
+		}
+		for _, v := range vars {
+			if !templateNameRe.MatchString(v) {
+				return &errorsx.ValidationError{Op: op, Field: field + ".text", Reason: "named variable {{" + v + "}} must be lowercase alphanumeric or underscore"}
+			}
+			if !have[v] {
+				return &errorsx.ValidationError{Op: op, Field: field + ".example", Reason: "missing sample for {{" + v + "}}"}
+			}
+		}
+		return nil
+	}
+
+	for i, v := range vars {
+		if v != fmt.Sprint(i+1) {
+			return &errorsx.ValidationError{Op: op, Field: field + ".text", Reason: "positional variables must be sequential starting at {{1}}"}
+		}
+	}
+	samples := 0
+	if ex != nil {
+		if header {
+			samples = len(ex.HeaderText)
+		} else if len(ex.BodyText) > 0 {
+			samples = len(ex.BodyText[0])
+		}
+	}
+	if samples != len(vars) {
+		return &errorsx.ValidationError{Op: op, Field: field + ".example", Reason: fmt.Sprintf("expected %d sample values, got %d", len(vars), samples)}
+	}
+	return nil
+}
+
+func validateTemplateButtons(op, field string, buttons []MessageTemplateButton) error {
+	if len(buttons) == 0 {
+		return &errorsx.ValidationError{Op: op, Field: field + ".buttons", Reason: "empty buttons"}
+	}
+	if len(buttons) > MaxTemplateButtons {
+		return &errorsx.ValidationError{Op: op, Field: field + ".buttons", Reason: fmt.Sprintf("at most %d buttons allowed", MaxTemplateButtons)}
+	}
+	for i, b := range buttons {
+		bf := fmt.Sprintf("%s.buttons[%d]", field, i)
+		if b.Type == "" {
+			return &errorsx.ValidationError{Op: op, Field: bf + ".type", Reason: "empty"}
+		}
+		if utf8.RuneCountInString(b.Text) > MaxTemplateButtonText {
+			return &errorsx.ValidationError{Op: op, Field: bf + ".text", Reason: fmt.Sprintf("must be at most %d characters", MaxTemplateButtonText)}
+		}
+		switch strings.ToUpper(b.Type) {
+		case "URL":
+			if b.URL == "" {
+				return &errorsx.ValidationError{Op: op, Field: bf + ".url", Reason: "empty"}
+			}
+		case "PHONE_NUMBER":
+			if b.PhoneNumber == "" {
+				return &errorsx.ValidationError{Op: op, Field: bf + ".phone_number", Reason: "empty"}
+			}
+		}
+	}
+	return nil
+}
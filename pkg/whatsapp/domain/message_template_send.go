@@ -0,0 +1,246 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/errorsx"
+)
+
+const validateTemplateSendOp = "ValidateTemplateSend"
+
+// templateButtonSubTypes maps the sub_type of a send-time button component to
+// the button types of the definition it may target.
+var templateButtonSubTypes = map[string][]string{
+	"url":         {"URL", "OTP"},
+	"quick_reply": {"QUICK_REPLY"},
+	"copy_code":   {"COPY_CODE"},
+	"flow":        {"FLOW"},
+	"catalog":     {"CATALOG"},
+	"mpm":         {"MPM"},
+	"voice_call":  {"VOICE_CALL"},
+}
+
+// ValidateSend checks that body supplies exactly what the template definition t
+// expects: header and body parameter counts, named vs positional parameters,
+// button indexes/sub types and the header media type. Errors carry field paths
+// relative to the send payload, e.g. "template.components[1].parameters[0].parameter_name".
+//
+// Carousel cards are only checked against the number of cards of the definition.
+func (t *MessageTemplate) ValidateSend(body *TemplateBody) error {
+	if t == nil {
+		return &errorsx.ValidationError{Op: validateTemplateSendOp, Reason: "nil template definition"}
+	}
+	if body == nil {
+		return &errorsx.ValidationError{Op: validateTemplateSendOp, Field: "template", Reason: "nil template"}
+	}
+	if body.Name != t.Name {
+		return &errorsx.ValidationError{Op: validateTemplateSendOp, Field: "template.name", Reason: fmt.Sprintf("definition is for %q", t.Name)}
+	}
+	if t.Status != "" && t.Status != TemplateStatusApproved {
+		return &errorsx.ValidationError{Op: validateTemplateSendOp, Field: "template.name", Reason: "template status is " + string(t.Status)}
+	}
+
+	named := t.ParameterFormat == TemplateParameterFormatNamed
+	header := t.component(TemplateComponentHeader)
+	bodyDef := t.component(TemplateComponentBody)
+	var buttons []MessageTemplateButton
+	if c := t.component(TemplateComponentButtons); c != nil {
+		buttons = c.Buttons
+	}
+
+	var sentHeader, sentBody bool
+	sentButtons := map[int]bool{}
+	for i, c := range body.Components {
+		field := fmt.Sprintf("template.components[%d]", i)
+		if c == nil {
+			return &errorsx.ValidationError{Op: validateTemplateSendOp, Field: field, Reason: "nil component"}
+		}
+		switch strings.ToLower(c.Type) {
+		case "header":
+			if sentHeader {
+				return &errorsx.ValidationError{Op: validateTemplateSendOp, Field: field + ".type", Reason: "duplicated header component"}
+			}
+			sentHeader = true
+			if err := validateSendHeader(field, c, header, named); err != nil {
+				return err
+			}
+		case "body":
+			if sentBody {
+				return &errorsx.ValidationError{Op: validateTemplateSendOp, Field: field + ".type", Reason: "duplicated body component"}
+			}
+			sentBody = true
+			text := ""
+			if bodyDef != nil {
+				text = bodyDef.Text
+			}
+			if err := validateSendParameters(field, c.Parameters, TemplatePlaceholders(text), named, "text", "currency", "date_time"); err != nil {
+				return err
+			}
+		case "button":
+			idx, err := validateSendButton(field, c, buttons)
+			if err != nil {
+				return err
+			}
+			if sentButtons[idx] {
+				return &errorsx.ValidationError{Op: validateTemplateSendOp, Field: field + ".index", Reason: fmt.Sprintf("duplicated button index %d", idx)}
+			}
+			sentButtons[idx] = true
+		case "carousel":
+			carousel := t.component(TemplateComponentCarousel)
+			if carousel == nil {
+				return &errorsx.ValidationError{Op: validateTemplateSendOp, Field: field + ".type", Reason: "template has no carousel"}
+			}
+			for j, card := range c.Cards {
+				if card == nil || card.CardIndex < 0 || card.CardIndex >= len(carousel.Cards) {
+					return &errorsx.ValidationError{Op: validateTemplateSendOp, Field: fmt.Sprintf("%s.cards[%d].card_index", field, j), Reason: fmt.Sprintf("template has %d cards", len(carousel.Cards))}
+				}
+			}
+		case "limited_time_offer":
+			if t.component(TemplateComponentLimitedTimeOffer) == nil {
+				return &errorsx.ValidationError{Op: validateTemplateSendOp, Field: field + ".type", Reason: "template has no limited-time offer"}
+			}
+		default:
+			return &errorsx.ValidationError{Op: validateTemplateSendOp, Field: field + ".type", Reason: "unknown component type " + c.Type}
+		}
+	}
+
+	if !sentHeader && header != nil && templateHeaderNeedsParameter(header) {
+		return &errorsx.ValidationError{Op: validateTemplateSendOp, Field: "template.components", Reason: "missing header component required by the template"}
+	}
+	if !sentBody && bodyDef != nil && len(TemplatePlaceholders(bodyDef.Text)) > 0 {
+		return &errorsx.ValidationError{Op: validateTemplateSendOp, Field: "template.components", Reason: "missing body component required by the template"}
+	}
+	for i, b := range buttons {
+		if !sentButtons[i] && templateButtonNeedsParameter(b) {
+			return &errorsx.ValidationError{Op: validateTemplateSendOp, Field: "template.components", Reason: fmt.Sprintf("missing button component for index %d (%s)", i, b.Type)}
+		}
+	}
+	return nil
+}
+
+func (t *MessageTemplate) component(kind string) *MessageTemplateComponent {
+	for i := range t.Components {
+		if strings.EqualFold(t.Components[i].Type, kind) {
+			return &t.Components[i]
+		}
+	}
+	return nil
+}
+
+func templateHeaderNeedsParameter(h *MessageTemplateComponent) bool {
+	if strings.EqualFold(h.Format, TemplateHeaderFormatText) {
+		return len(TemplatePlaceholders(h.Text)) > 0
+	}
+	return h.Format != ""
+}
+
+func templateButtonNeedsParameter(b MessageTemplateButton) bool {
+	switch strings.ToUpper(b.Type) {
+	case "URL":
+		return len(TemplatePlaceholders(b.URL)) > 0
+	case "COPY_CODE", "OTP":
+		return true
+	}
+	return false
+}
+
+func validateSendHeader(field string, c *TemplateComponent, def *MessageTemplateComponent, named bool) error {
+	if def == nil {
+		return &errorsx.ValidationError{Op: validateTemplateSendOp, Field: field + ".type", Reason: "template has no header"}
+	}
+	format := strings.ToUpper(def.Format)
+	if format == "" || format == TemplateHeaderFormatText {
+		return validateSendParameters(field, c.Parameters, TemplatePlaceholders(def.Text), named, "text")
+	}
+
+	// Media and location headers take exactly one parameter of the matching type.
+	if len(c.Parameters) != 1 {
+		return &errorsx.ValidationError{Op: validateTemplateSendOp, Field: field + ".parameters", Reason: fmt.Sprintf("%s header expects 1 parameter, got %d", format, len(c.Parameters))}
+	}
+	p := c.Parameters[0]
+	if p == nil {
+		return &errorsx.ValidationError{Op: validateTemplateSendOp, Field: field + ".parameters[0]", Reason: "nil parameter"}
+	}
+	if want := strings.ToLower(format); p.Type != want {
+		return &errorsx.ValidationError{Op: validateTemplateSendOp, Field: field + ".parameters[0].type", Reason: fmt.Sprintf("expected %q for %s header, got %q", want, format, p.Type)}
+	}
+	return nil
+}
+
+// validateSendParameters matches params against the variables of the definition
+// text. Named templates bind by parameter_name; positional ones by order.
+func validateSendParameters(field string, params []*TemplateParameter, vars []string, named bool, types ...string) error {
+	want := map[string]bool{}
+	for _, v := range vars {
+		want[v] = true
+	}
+	if len(params) != len(want) {
+		return &errorsx.ValidationError{Op: validateTemplateSendOp, Field: field + ".parameters", Reason: fmt.Sprintf("expected %d parameters, got %d", len(want), len(params))}
+	}
+
+	seen := map[string]bool{}
+	for i, p := range params {
+		pf := fmt.Sprintf("%s.parameters[%d]", field, i)
+		if p == nil {
+			return &errorsx.ValidationError{Op: validateTemplateSendOp, Field: pf, Reason: "nil parameter"}
+		}
+		if !slices.Contains(types, p.Type) {
+			return &errorsx.ValidationError{Op: validateTemplateSendOp, Field: pf + ".type", Reason: fmt.Sprintf("must be one of %s, got %q", strings.Join(types, ", "), p.Type)}
+		}
+		if !named {
+			if p.ParameterName != "" {
+				return &errorsx.ValidationError{Op: validateTemplateSendOp, Field: pf + ".parameter_name", Reason: "positional template does not accept named parameters"}
+			}
+			continue
+		}
+		switch {
+		case p.ParameterName == "":
+			return &errorsx.ValidationError{Op: validateTemplateSendOp, Field: pf + ".parameter_name", Reason: "named template requires parameter_name"}
+		case !want[p.ParameterName]:
+			return &errorsx.ValidationError{Op: validateTemplateSendOp, Field: pf + ".parameter_name", Reason: "unknown parameter {{" + p.ParameterName + "}}"}
+		case seen[p.ParameterName]:
+			return &errorsx.ValidationError{Op: validateTemplateSendOp, Field: pf + ".parameter_name", Reason: "duplicated parameter {{" + p.ParameterName + "}}"}
+		}
+		seen[p.ParameterName] = true
+	}
+	return nil
+}
+
+func validateSendButton(field string, c *TemplateComponent, buttons []MessageTemplateButton) (int, error) {
+	if c.Index == nil {
+		return 0, &errorsx.ValidationError{Op: validateTemplateSendOp, Field: field + ".index", Reason: "nil index"}
+	}
+	idx, err := strconv.Atoi(*c.Index)
+	if err != nil || idx < 0 {
+		return 0, &errorsx.ValidationError{Op: validateTemplateSendOp, Field: field + ".index", Reason: fmt.Sprintf("invalid index %q", *c.Index)}
+	}
+	if idx >= len(buttons) {
+		return 0, &errorsx.ValidationError{Op: validateTemplateSendOp, Field: field + ".index", Reason: fmt.Sprintf("template has %d buttons, index %d out of range", len(buttons), idx)}
+	}
+	def := buttons[idx]
+
+	subType := ""
+	if c.SubType != nil {
+		subType = strings.ToLower(*c.SubType)
+	}
+	allowed, known := templateButtonSubTypes[subType]
+	if !known {
+		return 0, &errorsx.ValidationError{Op: validateTemplateSendOp, Field: field + ".sub_type", Reason: fmt.Sprintf("unknown sub_type %q", subType)}
+	}
+	if !slices.Contains(allowed, strings.ToUpper(def.Type)) {
+		return 0, &errorsx.ValidationError{Op: validateTemplateSendOp, Field: field + ".sub_type", Reason: fmt.Sprintf("button %d is %s, not %s", idx, def.Type, subType)}
+	}
+
+	if strings.EqualFold(def.Type, "URL") {
+		vars := TemplatePlaceholders(def.URL)
+		if len(c.Parameters) != len(vars) {
+			return 0, &errorsx.ValidationError{Op: validateTemplateSendOp, Field: field + ".parameters", Reason: fmt.Sprintf("expected %d parameters, got %d", len(vars), len(c.Parameters))}
+		}
+	} else if len(c.Parameters) > 1 {
+		return 0, &errorsx.ValidationError{Op: validateTemplateSendOp, Field: field + ".parameters", Reason: fmt.Sprintf("expected at most 1 parameter, got %d", len(c.Parameters))}
+	}
+	return idx, nil
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/errorsx"
+)
+
+func newSendDefinition() *MessageTemplate {
+	return &MessageTemplate{
+		Name:     "order_update",
+		Language: "en_US",
+		Status:   TemplateStatusApproved,
+		Category: TemplateCategoryUtility,
+		Components: []MessageTemplateComponent{
+			{Type: TemplateComponentHeader, Format: TemplateHeaderFormatImage},
+			{Type: TemplateComponentBody, Text: "Hi {{1}}, your order ships on {{2}}."},
+			{Type: TemplateComponentButtons, Buttons: []MessageTemplateButton{
+				{Type: "QUICK_REPLY", Text: "Stop"},
+				{Type: "URL", Text: "Track", URL: "https://example.com/{{1}}"},
+			}},
+		},
+	}
+}
+
+func newSendBody() *TemplateBody {
+	text := func(s string) *TemplateParameter { return &TemplateParameter{Type: "text", Text: &s} }
+	str := func(s string) *string { return &s }
+	return &TemplateBody{
+		Name:     "order_update",
+		Language: &TemplateLanguage{Code: "en_US"},
+		Components: []*TemplateComponent{
+			{Type: "header", Parameters: []*TemplateParameter{{Type: "image", TemplateParameterImage: &TemplateParameterImage{Image: &TemplateParameterImageOptions{Link: "https://example.com/a.png"}}}}},
+			{Type: "body", Parameters: []*TemplateParameter{text("Ana"), text("Monday")}},
+			{Type: "button", SubType: str("url"), Index: str("1"), Parameters: []*TemplateParameter{text("42")}},
+		},
+	}
+}
+
+func TestMessageTemplateValidateSend(t *testing.T) {
+	if err := newSendDefinition().ValidateSend(newSendBody()); err != nil {
+		t.Fatalf("ValidateSend error: %v", err)
+	}
+
+	str := func(s string) *string { return &s }
+	tests := []struct {
+		name   string
+		def    func(*MessageTemplate)
+		modify func(*TemplateBody)
+		field  string
+	}{
+		{"not approved", func(d *MessageTemplate) { d.Status = TemplateStatusPaused }, nil, "template.name"},
+		{"body count", nil, func(b *TemplateBody) { b.Components[1].Parameters = b.Components[1].Parameters[:1] }, "template.components[1].parameters"},
+		{"body type", nil, func(b *TemplateBody) { b.Components[1].Parameters[0].Type = "image" }, "template.components[1].parameters[0].type"},
+		{"positional with name", nil, func(b *TemplateBody) { b.Components[1].Parameters[0].ParameterName = "first_name" }, "template.components[1].parameters[0].parameter_name"},
+		{"header media type", nil, func(b *TemplateBody) { b.Components[0].Parameters[0].Type = "video" }, "template.components[0].parameters[0].type"},
+		{"missing header", nil, func(b *TemplateBody) { b.Components = b.Components[1:] }, "template.components"},
+		{"button out of range", nil, func(b *TemplateBody) { b.Components[2].Index = str("2") }, "template.components[2].index"},
+		{"button sub type", nil, func(b *TemplateBody) { b.Components[2].SubType = str("quick_reply") }, "template.components[2].sub_type"},
+		{"missing dynamic url", nil, func(b *TemplateBody) { b.Components = b.Components[:2] }, "template.components"},
+		{"named without name", func(d *MessageTemplate) {
+			d.ParameterFormat = TemplateParameterFormatNamed
+			d.Components[1].Text = "Hi {{first_name}}, your order ships on {{day}}."
+		}, nil, "template.components[1].parameters[0].parameter_name"},
+		{"named unknown", func(d *MessageTemplate) {
+			d.ParameterFormat = TemplateParameterFormatNamed
+			d.Components[1].Text = "Hi {{first_name}}, your order ships on {{day}}."
+		}, func(b *TemplateBody) {
+			b.Components[1].Parameters[0].ParameterName = "first_name"
+			b.Components[1].Parameters[1].ParameterName = "date"
+		}, "template.components[1].parameters[1].parameter_name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			def, body := newSendDefinition(), newSendBody()
+			if tt.def != nil {
+				tt.def(def)
+			}
+			if tt.modify != nil {
+				tt.modify(body)
+			}
+			err := def.ValidateSend(body)
+			var ve *errorsx.ValidationError
+			if !errors.As(err, &ve) {
+				t.Fatalf("expected ValidationError, got %v", err)
+			}
+			if ve.Field != tt.field {
+				t.Fatalf("field = %q, want %q (%v)", ve.Field, tt.field, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/errorsx"
+)
+
+func newValidMessageTemplate() *MessageTemplate {
+	return &MessageTemplate{
+		Name:     "order_update",
+		Language: "en_US",
+		Category: TemplateCategoryUtility,
+		Components: []MessageTemplateComponent{
+			{Type: TemplateComponentHeader, Format: TemplateHeaderFormatText, Text: "Order {{1}}", Example: &MessageTemplateExample{HeaderText: []string{"#42"}}},
+			{Type: TemplateComponentBody, Text: "Hi {{1}}, your order ships on {{2}}.", Example: &MessageTemplateExample{BodyText: [][]string{{"Ana", "Monday"}}}},
+			{Type: TemplateComponentFooter, Text: "Thanks"},
+			{Type: TemplateComponentButtons, Buttons: []MessageTemplateButton{{Type: "URL", Text: "Track", URL: "https://example.com/{{1}}"}}},
+		},
+	}
+}
+
+func TestTemplatePlaceholders(t *testing.T) {
+	got := TemplatePlaceholders("Hi {{1}}, {{ first_name }} and {{2}}")
+	want := []string{"1", "first_name", "2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v want %v", got, want)
+	}
+}
+
+func TestMessageTemplateValidate(t *testing.T) {
+	if err := newValidMessageTemplate().Validate(); err != nil {
+		t.Fatalf("Validate error: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*MessageTemplate)
+		field  string
+	}{
+		{"bad name", func(m *MessageTemplate) { m.Name = "Order Update" }, "name"},
+		{"empty language", func(m *MessageTemplate) { m.Language = "" }, "language"},
+		{"bad category", func(m *MessageTemplate) { m.Category = "OTHER" }, "category"},
+		{"no body", func(m *MessageTemplate) { m.Components = m.Components[:1] }, "components"},
+		{"non sequential", func(m *MessageTemplate) { m.Components[1].Text = "Hi {{2}}" }, "components[1].text"},
+		{"missing samples", func(m *MessageTemplate) { m.Components[1].Example = nil }, "components[1].example"},
+		{"footer variable", func(m *MessageTemplate) { m.Components[2].Text = "Bye {{1}}" }, "components[2].text"},
+		{"media header without handle", func(m *MessageTemplate) {
+			m.Components[0] = MessageTemplateComponent{Type: TemplateComponentHeader, Format: TemplateHeaderFormatImage}
+		}, "components[0].example.header_handle"},
+		{"url button without url", func(m *MessageTemplate) { m.Components[3].Buttons[0].URL = "" }, "components[3].buttons[0].url"},
+		{"named missing sample", func(m *MessageTemplate) {
+			m.ParameterFormat = TemplateParameterFormatNamed
+			m.Components[0] = MessageTemplateComponent{Type: TemplateComponentHeader, Format: TemplateHeaderFormatText, Text: "Order"}
+			m.Components[1].Text = "Hi {{first_name}}"
+			m.Components[1].Example = &MessageTemplateExample{BodyTextNamedParams: []MessageTemplateNamedParam{{ParamName: "other", Example: "x"}}}
+		}, "components[1].example"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newValidMessageTemplate()
+			tt.modify(m)
+			err := m.Validate()
+			var ve *errorsx.ValidationError
+			if !errors.As(err, &ve) {
+				t.Fatalf("expected ValidationError, got %v", err)
+			}
+			if ve.Field != tt.field {
+				t.Fatalf("field = %q, want %q", ve.Field, tt.field)
+			}
+		})
+	}
+}
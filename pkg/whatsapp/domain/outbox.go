@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// OutboxStatus is the delivery state of an OutboxItem.
+type OutboxStatus string
+
+const (
+	OutboxPending OutboxStatus = "pending" // waiting to be claimed (possibly after NotBefore)
+	OutboxClaimed OutboxStatus = "claimed" // leased by a worker until LeaseUntil
+	OutboxSent    OutboxStatus = "sent"    // accepted by the API; WAMID is set
+	OutboxFailed  OutboxStatus = "failed"  // failed permanently; LastError explains why
+)
+
+// OutboxItem is an outbound message persisted in an outbox until it is sent.
+type OutboxItem struct {
+	// Key identifies the item and makes enqueueing idempotent: an item whose
+	// Key is already in the outbox is not added again.
+	Key     string       `json:"key"`
+	Message *SendMessage `json:"message"`
+
+	Status     OutboxStatus `json:"status"`
+	Attempts   int          `json:"attempts"` // number of times the item was claimed
+	WAMID      string       `json:"wamid,omitempty"`
+	LastError  string       `json:"last_error,omitempty"`
+	NotBefore  time.Time    `json:"not_before,omitzero"`
+	LeaseUntil time.Time    `json:"lease_until,omitzero"`
+	CreatedAt  time.Time    `json:"created_at"`
+	UpdatedAt  time.Time    `json:"updated_at"`
+}
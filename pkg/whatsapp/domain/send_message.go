@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
-	"regexp"
 
 	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/errorsx"
 	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/utils"
@@ -32,6 +31,7 @@ type SendMessage struct {
 	*ContactMessage
 	*LocationMessage
 	*TemplateMessage
+	*InteractiveMessage
 }
 
 func (s *SendMessage) Buffer() (*bytes.Buffer, error) {
@@ -44,7 +44,7 @@ func (s *SendMessage) Buffer() (*bytes.Buffer, error) {
 }
 
 func (s *SendMessage) Validate() error {
-	s.To = regexp.MustCompile("[^0-9]").ReplaceAllString(s.To, "")
+	s.To = utils.OnlyDigits(s.To)
 	if s.To == "" {
 		return &errorsx.ValidationError{Op: "SendMessage", Field: "to", Reason: "empty"}
 	}
@@ -110,6 +110,17 @@ func (s *SendMessage) Validate() error {
 			return err
 		}
 		break
+	case "interactive":
+		if err := s.validateInteractiveMessage(); err != nil {
+			return err
+		}
+		break
 	}
 	return nil
 }
+
+// hasReference reports which media reference (uploaded ID or public link) is set.
+// Nil and empty pointers are treated as absent.
+func hasReference(id, link *string) (hasID, hasLink bool) {
+	return id != nil && *id != "", link != nil && *link != ""
+}
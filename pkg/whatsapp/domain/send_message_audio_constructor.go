@@ -8,8 +8,8 @@ type AudioMessage struct {
 	Audio *AudioBody `json:"audio"`
 }
 type AudioBody struct {
-	Id   *string `json:"id"`
-	Link *string `json:"link"`
+	Id   *string `json:"id,omitempty"`
+	Link *string `json:"link,omitempty"`
 }
 
 func NewSendAudioRequest(to, imageID, imageURL string) *SendMessage {
@@ -61,11 +61,12 @@ func (s *SendMessage) validateAudioMessage() error {
 		return &errorsx.ValidationError{Field: "Audio", Reason: "audio is nil", Op: "validateAudioMessage"}
 	}
 
-	if *s.AudioMessage.Audio.Id == "" && *s.AudioMessage.Audio.Link == "" {
+	hasID, hasLink := hasReference(s.AudioMessage.Audio.Id, s.AudioMessage.Audio.Link)
+	if !hasID && !hasLink {
 		return &errorsx.ValidationError{Field: "Audio", Reason: "the reference must be a link or a Id, nothing received", Op: "validateAudioMessage"}
 	}
 
-	if *s.AudioMessage.Audio.Id != "" && *s.AudioMessage.Audio.Link != "" {
+	if hasID && hasLink {
 		return &errorsx.ValidationError{Field: "Audio", Reason: "the reference must be a link or a Id, both received", Op: "validateAudioMessage"}
 	}
 
@@ -6,8 +6,8 @@ type DocumentMessage struct {
 	Document *DocumentBody `json:"document"`
 }
 type DocumentBody struct {
-	Id       *string `json:"id"`
-	Link     *string `json:"link"`
+	Id       *string `json:"id,omitempty"`
+	Link     *string `json:"link,omitempty"`
 	Caption  string  `json:"caption"`
 	Filename string  `json:"filename"`
 }
@@ -6,8 +6,8 @@ type ImageMessage struct {
 	Image *ImageBody `json:"image"`
 }
 type ImageBody struct {
-	Id   *string `json:"id"`
-	Link *string `json:"link"`
+	Id   *string `json:"id,omitempty"`
+	Link *string `json:"link,omitempty"`
 }
 
 func NewSendImageRequest(to, imageID, imageURL string) *SendMessage {
@@ -56,11 +56,12 @@ func (s *SendMessage) validateImageMessage() error {
 		return &errorsx.ValidationError{Field: "Image", Reason: "image is nil", Op: "validateImageMessage"}
 	}
 
-	if *s.ImageMessage.Image.Id == "" && *s.ImageMessage.Image.Link == "" {
+	hasID, hasLink := hasReference(s.ImageMessage.Image.Id, s.ImageMessage.Image.Link)
+	if !hasID && !hasLink {
 		return &errorsx.ValidationError{Field: "Image", Reason: "the reference must be a link or a Id, nothing received", Op: "validateImageMessage"}
 	}
 
-	if *s.ImageMessage.Image.Id != "" && *s.ImageMessage.Image.Link != "" {
+	if hasID && hasLink {
 		return &errorsx.ValidationError{Field: "Image", Reason: "the reference must be a link or a Id, both received", Op: "validateImageMessage"}
 	}
 	return nil
@@ -0,0 +1,369 @@
+package domain
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/errorsx"
+)
+
+// Limits enforced by the Cloud API for interactive messages. Lengths are counted
+// in characters (runes), not bytes.
+const (
+	MaxInteractiveButtons        = 3
+	MaxInteractiveButtonTitle    = 20
+	MaxInteractiveButtonID       = 256
+	MaxInteractiveListSections   = 10
+	MaxInteractiveListRows       = 10
+	MaxInteractiveListButtonText = 20
+	MaxInteractiveSectionTitle   = 24
+	MaxInteractiveRowTitle       = 24
+	MaxInteractiveRowDescription = 72
+	MaxInteractiveRowID          = 200
+	MaxInteractiveHeaderText     = 60
+	MaxInteractiveBodyText       = 1024
+	MaxInteractiveFooterText     = 60
+)
+
+// Interactive payload kinds and header types.
+const (
+	InteractiveTypeButton         = "button"
+	InteractiveTypeList           = "list"
+	InteractiveHeaderTypeText     = "text"
+	InteractiveHeaderTypeImage    = "image"
+	InteractiveHeaderTypeVideo    = "video"
+	InteractiveHeaderTypeDocument = "document"
+
+	interactiveReplyButtonType = "reply"
+)
+
+type InteractiveMessage struct {
+	Interactive *InteractiveBody `json:"interactive"`
+}
+
+// InteractiveBody is the union of the reply-button and list payloads. Type selects
+// which Action fields are relevant: Buttons for "button", Button+Sections for "list".
+type InteractiveBody struct {
+	Type   string             `json:"type"`
+	Header *InteractiveHeader `json:"header,omitempty"`
+	Body   *InteractiveText   `json:"body"`
+	Footer *InteractiveText   `json:"footer,omitempty"`
+	Action *InteractiveAction `json:"action"`
+}
+
+type InteractiveText struct {
+	Text string `json:"text"`
+}
+
+// InteractiveHeader supports text, image, video and document headers. Only the
+// field matching Type should be set; list messages accept text headers only.
+type InteractiveHeader struct {
+	Type     string                  `json:"type"`
+	Text     string                  `json:"text,omitempty"`
+	Image    *InteractiveHeaderMedia `json:"image,omitempty"`
+	Video    *InteractiveHeaderMedia `json:"video,omitempty"`
+	Document *InteractiveHeaderMedia `json:"document,omitempty"`
+}
+
+type InteractiveHeaderMedia struct {
+	Id       *string `json:"id,omitempty"`
+	Link     *string `json:"link,omitempty"`
+	Filename string  `json:"filename,omitempty"`
+}
+
+type InteractiveAction struct {
+	Buttons  []*InteractiveButton  `json:"buttons,omitempty"`
+	Button   string                `json:"button,omitempty"`
+	Sections []*InteractiveSection `json:"sections,omitempty"`
+}
+
+type InteractiveButton struct {
+	Type  string                  `json:"type"`
+	Reply *InteractiveReplyButton `json:"reply"`
+}
+
+type InteractiveReplyButton struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+type InteractiveSection struct {
+	Title string            `json:"title,omitempty"`
+	Rows  []*InteractiveRow `json:"rows"`
+}
+
+type InteractiveRow struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+}
+
+func NewInteractiveTextHeader(text string) *InteractiveHeader {
+	return &InteractiveHeader{Type: InteractiveHeaderTypeText, Text: text}
+}
+
+func NewInteractiveImageHeader(imageID, imageURL string) *InteractiveHeader {
+	return &InteractiveHeader{Type: InteractiveHeaderTypeImage, Image: newInteractiveHeaderMedia(imageID, imageURL, "")}
+}
+
+func NewInteractiveVideoHeader(videoID, videoURL string) *InteractiveHeader {
+	return &InteractiveHeader{Type: InteractiveHeaderTypeVideo, Video: newInteractiveHeaderMedia(videoID, videoURL, "")}
+}
+
+func NewInteractiveDocumentHeader(documentID, documentURL, fileName string) *InteractiveHeader {
+	return &InteractiveHeader{Type: InteractiveHeaderTypeDocument, Document: newInteractiveHeaderMedia(documentID, documentURL, fileName)}
+}
+
+func newInteractiveHeaderMedia(id, link, fileName string) *InteractiveHeaderMedia {
+	m := &InteractiveHeaderMedia{Filename: fileName}
+	if id != "" {
+		m.Id = &id
+	} else {
+		m.Link = &link
+	}
+	return m
+}
+
+// NewInteractiveReplyButton builds a quick-reply button. The id is echoed back in
+// the inbound button_reply when the user taps it.
+func NewInteractiveReplyButton(id, title string) *InteractiveButton {
+	return &InteractiveButton{Type: interactiveReplyButtonType, Reply: &InteractiveReplyButton{ID: id, Title: title}}
+}
+
+func NewSendInteractiveButtonsRequest(to string, header *InteractiveHeader, body, footer string, buttons []*InteractiveButton) *SendMessage {
+	rt := "individual"
+	return &SendMessage{
+		MessagingProduct: "whatsapp",
+		RecipientType:    &rt,
+		To:               to,
+		Type:             "interactive",
+		InteractiveMessage: &InteractiveMessage{
+			Interactive: newInteractiveBody(InteractiveTypeButton, header, body, footer, &InteractiveAction{Buttons: buttons}),
+		},
+	}
+}
+
+func NewSendContextInteractiveButtonsRequest(to string, header *InteractiveHeader, body, footer string, buttons []*InteractiveButton, targetMessage string) *SendMessage {
+	msg := NewSendInteractiveButtonsRequest(to, header, body, footer, buttons)
+	msg.ContextMessage = &ContextMessage{Context: &Context{MessageId: targetMessage}}
+	return msg
+}
+
+func NewSendInteractiveListRequest(to string, header *InteractiveHeader, body, footer, buttonText string, sections []*InteractiveSection) *SendMessage {
+	rt := "individual"
+	return &SendMessage{
+		MessagingProduct: "whatsapp",
+		RecipientType:    &rt,
+		To:               to,
+		Type:             "interactive",
+		InteractiveMessage: &InteractiveMessage{
+			Interactive: newInteractiveBody(InteractiveTypeList, header, body, footer, &InteractiveAction{Button: buttonText, Sections: sections}),
+		},
+	}
+}
+
+func NewSendContextInteractiveListRequest(to string, header *InteractiveHeader, body, footer, buttonText string, sections []*InteractiveSection, targetMessage string) *SendMessage {
+	msg := NewSendInteractiveListRequest(to, header, body, footer, buttonText, sections)
+	msg.ContextMessage = &ContextMessage{Context: &Context{MessageId: targetMessage}}
+	return msg
+}
+
+func newInteractiveBody(kind string, header *InteractiveHeader, body, footer string, action *InteractiveAction) *InteractiveBody {
+	ib := &InteractiveBody{
+		Type:   kind,
+		Header: header,
+		Body:   &InteractiveText{Text: body},
+		Action: action,
+	}
+	if footer != "" {
+		ib.Footer = &InteractiveText{Text: footer}
+	}
+	return ib
+}
+
+func (s *SendMessage) validateInteractiveMessage() error {
+	const op = "validateInteractiveMessage"
+	if s.Type != "interactive" {
+		return &errorsx.ValidationError{Op: op, Field: "Type", Reason: "type must be interactive"}
+	}
+	if s.InteractiveMessage == nil || s.InteractiveMessage.Interactive == nil {
+		return &errorsx.ValidationError{Op: op, Field: "Interactive", Reason: "interactive is nil"}
+	}
+	in := s.InteractiveMessage.Interactive
+
+	if in.Body == nil || in.Body.Text == "" {
+		return &errorsx.ValidationError{Op: op, Field: "Interactive.Body.Text", Reason: "empty"}
+	}
+	if err := checkLen(op, "Interactive.Body.Text", in.Body.Text, MaxInteractiveBodyText); err != nil {
+		return err
+	}
+	if in.Footer != nil {
+		if err := checkLen(op, "Interactive.Footer.Text", in.Footer.Text, MaxInteractiveFooterText); err != nil {
+			return err
+		}
+	}
+	if in.Header != nil {
+		if err := validateInteractiveHeader(in.Type, in.Header); err != nil {
+			return err
+		}
+	}
+	if in.Action == nil {
+		return &errorsx.ValidationError{Op: op, Field: "Interactive.Action", Reason: "action is nil"}
+	}
+
+	switch in.Type {
+	case InteractiveTypeButton:
+		return validateInteractiveButtons(in.Action.Buttons)
+	case InteractiveTypeList:
+		return validateInteractiveList(in.Action)
+	default:
+		return &errorsx.ValidationError{Op: op, Field: "Interactive.Type", Reason: "must be button or list"}
+	}
+}
+
+func validateInteractiveHeader(kind string, h *InteractiveHeader) error {
+	const op = "validateInteractiveMessage"
+	if kind == InteractiveTypeList && h.Type != InteractiveHeaderTypeText {
+		return &errorsx.ValidationError{Op: op, Field: "Interactive.Header.Type", Reason: "list messages only support text headers"}
+	}
+
+	var media *InteractiveHeaderMedia
+	switch h.Type {
+	case InteractiveHeaderTypeText:
+		if h.Text == "" {
+			return &errorsx.ValidationError{Op: op, Field: "Interactive.Header.Text", Reason: "empty"}
+		}
+		return checkLen(op, "Interactive.Header.Text", h.Text, MaxInteractiveHeaderText)
+	case InteractiveHeaderTypeImage:
+		media = h.Image
+	case InteractiveHeaderTypeVideo:
+		media = h.Video
+	case InteractiveHeaderTypeDocument:
+		media = h.Document
+	default:
+		return &errorsx.ValidationError{Op: op, Field: "Interactive.Header.Type", Reason: "must be text, image, video or document"}
+	}
+
+	field := "Interactive.Header." + h.Type
+	if media == nil {
+		return &errorsx.ValidationError{Op: op, Field: field, Reason: "media is nil"}
+	}
+	hasID, hasLink := hasReference(media.Id, media.Link)
+	if !hasID && !hasLink {
+		return &errorsx.ValidationError{Op: op, Field: field, Reason: "the reference must be a link or a Id, nothing received"}
+	}
+	if hasID && hasLink {
+		return &errorsx.ValidationError{Op: op, Field: field, Reason: "the reference must be a link or a Id, both received"}
+	}
+	return nil
+}
+
+func validateInteractiveButtons(buttons []*InteractiveButton) error {
+	const op = "validateInteractiveMessage"
+	if len(buttons) == 0 {
+		return &errorsx.ValidationError{Op: op, Field: "Interactive.Action.Buttons", Reason: "must have at least one button"}
+	}
+	if len(buttons) > MaxInteractiveButtons {
+		return &errorsx.ValidationError{Op: op, Field: "Interactive.Action.Buttons", Reason: fmt.Sprintf("at most %d buttons allowed", MaxInteractiveButtons)}
+	}
+
+	seen := make(map[string]struct{}, len(buttons))
+	for i, b := range buttons {
+		field := fmt.Sprintf("Interactive.Action.Buttons[%d]", i)
+		if b == nil || b.Reply == nil {
+			return &errorsx.ValidationError{Op: op, Field: field, Reason: "nil button"}
+		}
+		if b.Type != interactiveReplyButtonType {
+			return &errorsx.ValidationError{Op: op, Field: field + ".Type", Reason: "must be reply"}
+		}
+		if b.Reply.ID == "" {
+			return &errorsx.ValidationError{Op: op, Field: field + ".Reply.ID", Reason: "empty"}
+		}
+		if err := checkLen(op, field+".Reply.ID", b.Reply.ID, MaxInteractiveButtonID); err != nil {
+			return err
+		}
+		if _, dup := seen[b.Reply.ID]; dup {
+			return &errorsx.ValidationError{Op: op, Field: field + ".Reply.ID", Reason: "duplicated id"}
+		}
+		seen[b.Reply.ID] = struct{}{}
+		if b.Reply.Title == "" {
+			return &errorsx.ValidationError{Op: op, Field: field + ".Reply.Title", Reason: "empty"}
+		}
+		if err := checkLen(op, field+".Reply.Title", b.Reply.Title, MaxInteractiveButtonTitle); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateInteractiveList(action *InteractiveAction) error {
+	const op = "validateInteractiveMessage"
+	if action.Button == "" {
+		return &errorsx.ValidationError{Op: op, Field: "Interactive.Action.Button", Reason: "empty"}
+	}
+	if err := checkLen(op, "Interactive.Action.Button", action.Button, MaxInteractiveListButtonText); err != nil {
+		return err
+	}
+	if len(action.Sections) == 0 {
+		return &errorsx.ValidationError{Op: op, Field: "Interactive.Action.Sections", Reason: "must have at least one section"}
+	}
+	if len(action.Sections) > MaxInteractiveListSections {
+		return &errorsx.ValidationError{Op: op, Field: "Interactive.Action.Sections", Reason: fmt.Sprintf("at most %d sections allowed", MaxInteractiveListSections)}
+	}
+
+	rows := 0
+	seen := make(map[string]struct{})
+	for i, sec := range action.Sections {
+		field := fmt.Sprintf("Interactive.Action.Sections[%d]", i)
+		if sec == nil {
+			return &errorsx.ValidationError{Op: op, Field: field, Reason: "nil section"}
+		}
+		if len(action.Sections) > 1 && sec.Title == "" {
+			return &errorsx.ValidationError{Op: op, Field: field + ".Title", Reason: "required when more than one section"}
+		}
+		if err := checkLen(op, field+".Title", sec.Title, MaxInteractiveSectionTitle); err != nil {
+			return err
+		}
+		if len(sec.Rows) == 0 {
+			return &errorsx.ValidationError{Op: op, Field: field + ".Rows", Reason: "must have at least one row"}
+		}
+		for j, row := range sec.Rows {
+			rf := fmt.Sprintf("%s.Rows[%d]", field, j)
+			if row == nil {
+				return &errorsx.ValidationError{Op: op, Field: rf, Reason: "nil row"}
+			}
+			if row.ID == "" {
+				return &errorsx.ValidationError{Op: op, Field: rf + ".ID", Reason: "empty"}
+			}
+			if err := checkLen(op, rf+".ID", row.ID, MaxInteractiveRowID); err != nil {
+				return err
+			}
+			if _, dup := seen[row.ID]; dup {
+				return &errorsx.ValidationError{Op: op, Field: rf + ".ID", Reason: "duplicated id"}
+			}
+			seen[row.ID] = struct{}{}
+			if row.Title == "" {
+				return &errorsx.ValidationError{Op: op, Field: rf + ".Title", Reason: "empty"}
+			}
+			if err := checkLen(op, rf+".Title", row.Title, MaxInteractiveRowTitle); err != nil {
+				return err
+			}
+			if err := checkLen(op, rf+".Description", row.Description, MaxInteractiveRowDescription); err != nil {
+				return err
+			}
+		}
+		rows += len(sec.Rows)
+	}
+	if rows > MaxInteractiveListRows {
+		return &errorsx.ValidationError{Op: op, Field: "Interactive.Action.Sections", Reason: fmt.Sprintf("at most %d rows allowed across all sections", MaxInteractiveListRows)}
+	}
+	return nil
+}
+
+// checkLen returns a ValidationError when value exceeds max characters.
+func checkLen(op, field, value string, max int) error {
+	if n := utf8.RuneCountInString(value); n > max {
+		return &errorsx.ValidationError{Op: op, Field: field, Reason: fmt.Sprintf("must be at most %d characters, got %d", max, n)}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func newValidButtonsMessage() *SendMessage {
+	return NewSendInteractiveButtonsRequest("+123456789", NewInteractiveTextHeader("Menu"), "Pick one", "footer",
+		[]*InteractiveButton{NewInteractiveReplyButton("yes", "Yes"), NewInteractiveReplyButton("no", "No")})
+}
+
+func newValidListMessage() *SendMessage {
+	return NewSendInteractiveListRequest("+123456789", NewInteractiveTextHeader("Menu"), "Pick one", "", "Options",
+		[]*InteractiveSection{
+			{Title: "Food", Rows: []*InteractiveRow{{ID: "pizza", Title: "Pizza", Description: "Cheesy"}}},
+			{Title: "Drinks", Rows: []*InteractiveRow{{ID: "soda", Title: "Soda"}}},
+		})
+}
+
+func TestNewSendInteractiveButtonsRequest(t *testing.T) {
+	msg := newValidButtonsMessage()
+	if msg.Type != "interactive" || msg.Interactive.Type != InteractiveTypeButton {
+		t.Fatalf("unexpected type %s/%s", msg.Type, msg.Interactive.Type)
+	}
+	if err := msg.Validate(); err != nil {
+		t.Fatalf("Validate error: %v", err)
+	}
+	buf, err := msg.Buffer()
+	if err != nil {
+		t.Fatalf("Buffer error: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"buttons":[{"type":"reply","reply":{"id":"yes","title":"Yes"}}`) {
+		t.Fatalf("unexpected payload: %s", buf.String())
+	}
+}
+
+func TestNewSendInteractiveListRequest(t *testing.T) {
+	msg := newValidListMessage()
+	if err := msg.Validate(); err != nil {
+		t.Fatalf("Validate error: %v", err)
+	}
+	ctxMsg := NewSendContextInteractiveListRequest("+123456789", nil, "b", "", "Open", msg.Interactive.Action.Sections, "m1")
+	if ctxMsg.ContextMessage == nil || ctxMsg.ContextMessage.Context.MessageId != "m1" {
+		t.Fatalf("context not set")
+	}
+}
+
+func TestNewInteractiveMediaHeaders(t *testing.T) {
+	for _, h := range []*InteractiveHeader{
+		NewInteractiveImageHeader("id", ""),
+		NewInteractiveVideoHeader("", "https://x/v.mp4"),
+		NewInteractiveDocumentHeader("id", "", "a.pdf"),
+	} {
+		msg := NewSendInteractiveButtonsRequest("+123456789", h, "b", "", []*InteractiveButton{NewInteractiveReplyButton("a", "A")})
+		if err := msg.Validate(); err != nil {
+			t.Fatalf("%s header: %v", h.Type, err)
+		}
+	}
+}
+
+func TestValidateInteractiveButtonsErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*SendMessage)
+	}{
+		{"wrong type", func(m *SendMessage) { m.Type = "text" }},
+		{"nil body", func(m *SendMessage) { m.InteractiveMessage = nil }},
+		{"empty body text", func(m *SendMessage) { m.Interactive.Body.Text = "" }},
+		{"long footer", func(m *SendMessage) { m.Interactive.Footer.Text = strings.Repeat("f", MaxInteractiveFooterText+1) }},
+		{"nil action", func(m *SendMessage) { m.Interactive.Action = nil }},
+		{"unknown kind", func(m *SendMessage) { m.Interactive.Type = "carousel" }},
+		{"no buttons", func(m *SendMessage) { m.Interactive.Action.Buttons = nil }},
+		{"too many buttons", func(m *SendMessage) {
+			for i := 0; i < MaxInteractiveButtons; i++ {
+				m.Interactive.Action.Buttons = append(m.Interactive.Action.Buttons, NewInteractiveReplyButton(strings.Repeat("x", i+1), "X"))
+			}
+		}},
+		{"long title", func(m *SendMessage) {
+			m.Interactive.Action.Buttons[0].Reply.Title = strings.Repeat("t", MaxInteractiveButtonTitle+1)
+		}},
+		{"duplicated id", func(m *SendMessage) { m.Interactive.Action.Buttons[1].Reply.ID = "yes" }},
+		{"empty header text", func(m *SendMessage) { m.Interactive.Header.Text = "" }},
+		{"media header without ref", func(m *SendMessage) {
+			m.Interactive.Header = &InteractiveHeader{Type: "image", Image: &InteractiveHeaderMedia{}}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := newValidButtonsMessage()
+			tt.modify(msg)
+			if err := msg.validateInteractiveMessage(); err == nil {
+				t.Fatalf("expected error")
+			}
+		})
+	}
+}
+
+func TestValidateInteractiveListErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*SendMessage)
+	}{
+		{"empty button text", func(m *SendMessage) { m.Interactive.Action.Button = "" }},
+		{"no sections", func(m *SendMessage) { m.Interactive.Action.Sections = nil }},
+		{"media header", func(m *SendMessage) { m.Interactive.Header = NewInteractiveImageHeader("id", "") }},
+		{"missing section title", func(m *SendMessage) { m.Interactive.Action.Sections[1].Title = "" }},
+		{"empty rows", func(m *SendMessage) { m.Interactive.Action.Sections[0].Rows = nil }},
+		{"long row description", func(m *SendMessage) {
+			m.Interactive.Action.Sections[0].Rows[0].Description = strings.Repeat("d", MaxInteractiveRowDescription+1)
+		}},
+		{"duplicated row id", func(m *SendMessage) { m.Interactive.Action.Sections[1].Rows[0].ID = "pizza" }},
+		{"too many rows", func(m *SendMessage) {
+			for i := 0; i < MaxInteractiveListRows; i++ {
+				m.Interactive.Action.Sections[0].Rows = append(m.Interactive.Action.Sections[0].Rows, &InteractiveRow{ID: strings.Repeat("r", i+1), Title: "R"})
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := newValidListMessage()
+			tt.modify(msg)
+			if err := msg.validateInteractiveMessage(); err == nil {
+				t.Fatalf("expected error")
+			}
+		})
+	}
+}
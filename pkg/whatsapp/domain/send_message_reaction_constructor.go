@@ -30,9 +30,12 @@ func NewSendReplyReaction(to, messageID, emoji string) *SendMessage {
 }
 
 func (s *SendMessage) validateReplyMessage() *errorsx.ValidationError {
-	if s.Type == "reaction" {
+	if s.Type != "reaction" {
 		return &errorsx.ValidationError{Op: "validateReplyMessage", Field: "Type", Reason: "must be reaction"}
 	}
+	if s.ReactionMessage == nil || s.Reaction == nil {
+		return &errorsx.ValidationError{Op: "validateReplyMessage", Field: "Reaction", Reason: "nil reaction"}
+	}
 	if s.Reaction.Emoji == "" {
 		return &errorsx.ValidationError{Op: "validateReplyMessage", Field: "Emoji", Reason: "empty"}
 	}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/errorsx"
+)
+
+// MarkReadRequest marks an inbound message (and every earlier message in the
+// conversation) as read. With TypingIndicator set, the user also sees a typing
+// indicator until the next message is sent or ~25 seconds pass.
+type MarkReadRequest struct {
+	MessagingProduct string           `json:"messaging_product"`
+	Status           string           `json:"status"`
+	MessageID        string           `json:"message_id"`
+	TypingIndicator  *TypingIndicator `json:"typing_indicator,omitempty"`
+}
+
+type TypingIndicator struct {
+	Type string `json:"type"`
+}
+
+func NewMarkReadRequest(messageID string, withTyping bool) *MarkReadRequest {
+	r := &MarkReadRequest{
+		MessagingProduct: "whatsapp",
+		Status:           "read",
+		MessageID:        messageID,
+	}
+	if withTyping {
+		r.TypingIndicator = &TypingIndicator{Type: "text"}
+	}
+	return r
+}
+
+func (r *MarkReadRequest) Buffer() (*bytes.Buffer, error) {
+	buf := &bytes.Buffer{}
+	if err := json.NewEncoder(buf).Encode(r); err != nil {
+		return nil, fmt.Errorf("encode request: %w", err)
+	}
+	return buf, nil
+}
+
+func (r *MarkReadRequest) Validate() error {
+	if r.MessageID == "" {
+		return &errorsx.ValidationError{Op: "MarkRead", Field: "message_id", Reason: "empty"}
+	}
+	return nil
+}
@@ -6,8 +6,8 @@ type StickerMessage struct {
 	Sticker *StickerBody `json:"sticker"`
 }
 type StickerBody struct {
-	Id   *string `json:"id"`
-	Link *string `json:"link"`
+	Id   *string `json:"id,omitempty"`
+	Link *string `json:"link,omitempty"`
 }
 
 func NewSendStickerRequest(to, imageID, imageURL string) *SendMessage {
@@ -59,11 +59,12 @@ func (s *SendMessage) validateStickerMessage() error {
 		return &errorsx.ValidationError{Field: "Sticker", Reason: "sticker is nil", Op: "validateStickerMessage"}
 	}
 
-	if *s.StickerMessage.Sticker.Id == "" && *s.StickerMessage.Sticker.Link == "" {
+	hasID, hasLink := hasReference(s.StickerMessage.Sticker.Id, s.StickerMessage.Sticker.Link)
+	if !hasID && !hasLink {
 		return &errorsx.ValidationError{Field: "Sticker", Reason: "the reference must be a link or a Id, nothing received", Op: "validateStickerMessage"}
 	}
 
-	if *s.StickerMessage.Sticker.Id != "" && *s.StickerMessage.Sticker.Link != "" {
+	if hasID && hasLink {
 		return &errorsx.ValidationError{Field: "Sticker", Reason: "the reference must be a link or a Id, both received", Op: "validateStickerMessage"}
 	}
 	return nil
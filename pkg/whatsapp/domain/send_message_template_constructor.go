@@ -15,18 +15,34 @@ type TemplateLanguage struct {
 }
 type TemplateComponent struct {
 	Type       string               `json:"type"`
-	SubType    *string              `json:"sub_type"`
-	Index      *string              `json:"index"`
-	Parameters []*TemplateParameter `json:"parameters"`
+	SubType    *string              `json:"sub_type,omitempty"`
+	Index      *string              `json:"index,omitempty"`
+	Parameters []*TemplateParameter `json:"parameters,omitempty"`
+	Cards      []*TemplateCard      `json:"cards,omitempty"`
+}
+
+// TemplateCard is one card of a carousel component; its components follow the
+// same rules as top-level ones (header, body and buttons).
+type TemplateCard struct {
+	CardIndex  int                  `json:"card_index"`
+	Components []*TemplateComponent `json:"components"`
 }
 
 type TemplateParameter struct {
-	Type    string  `json:"type"`
-	Text    *string `json:"text"`
-	Payload *string `json:"payload"`
+	Type string `json:"type"`
+	// ParameterName binds the value to a {{name}} variable of a NAMED template.
+	ParameterName    string                             `json:"parameter_name,omitempty"`
+	Text             *string                            `json:"text,omitempty"`
+	Payload          *string                            `json:"payload,omitempty"`
+	CouponCode       *string                            `json:"coupon_code,omitempty"`
+	Action           *TemplateParameterAction           `json:"action,omitempty"`
+	LimitedTimeOffer *TemplateParameterLimitedTimeOffer `json:"limited_time_offer,omitempty"`
 	*TemplateParameterCurrency
 	*TemplateParameterDateTime
 	*TemplateParameterImage
+	*TemplateParameterVideo
+	*TemplateParameterDocument
+	*TemplateParameterLocation
 }
 
 type TemplateParameterCurrency struct {
@@ -45,21 +61,67 @@ type TemplateParameterDateTime struct {
 
 type TemplateParameterDateTimeOptions struct {
 	FallbackValue string `json:"fallback_value"`
-	DayOfWeek     int    `json:"day_of_week"`
-	Year          int    `json:"year"`
-	Month         int    `json:"month"`
-	DayOfMonth    int    `json:"day_of_month"`
-	Hour          int    `json:"hour"`
-	Minute        int    `json:"minute"`
-	Calendar      string `json:"calendar"`
+	DayOfWeek     int    `json:"day_of_week,omitempty"`
+	Year          int    `json:"year,omitempty"`
+	Month         int    `json:"month,omitempty"`
+	DayOfMonth    int    `json:"day_of_month,omitempty"`
+	Hour          int    `json:"hour,omitempty"`
+	Minute        int    `json:"minute,omitempty"`
+	Calendar      string `json:"calendar,omitempty"`
 }
 
 type TemplateParameterImage struct {
 	Image *TemplateParameterImageOptions `json:"image"`
 }
 
+// TemplateParameterImageOptions references the media by uploaded ID or by link.
 type TemplateParameterImageOptions struct {
-	Link string `json:"link"`
+	ID   string `json:"id,omitempty"`
+	Link string `json:"link,omitempty"`
+}
+
+type TemplateParameterVideo struct {
+	Video *TemplateParameterVideoOptions `json:"video"`
+}
+
+type TemplateParameterVideoOptions struct {
+	ID   string `json:"id,omitempty"`
+	Link string `json:"link,omitempty"`
+}
+
+type TemplateParameterDocument struct {
+	Document *TemplateParameterDocumentOptions `json:"document"`
+}
+
+type TemplateParameterDocumentOptions struct {
+	ID       string `json:"id,omitempty"`
+	Link     string `json:"link,omitempty"`
+	Filename string `json:"filename,omitempty"`
+}
+
+type TemplateParameterLocation struct {
+	Location *TemplateParameterLocationOptions `json:"location"`
+}
+
+type TemplateParameterLocationOptions struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Name      string  `json:"name,omitempty"`
+	Address   string  `json:"address,omitempty"`
+}
+
+// TemplateParameterAction is the parameter of catalog, multi-product and flow
+// buttons.
+type TemplateParameterAction struct {
+	ThumbnailProductRetailerID string         `json:"thumbnail_product_retailer_id,omitempty"`
+	FlowToken                  string         `json:"flow_token,omitempty"`
+	FlowActionData             map[string]any `json:"flow_action_data,omitempty"`
+}
+
+// TemplateParameterLimitedTimeOffer sets the expiration of a limited-time-offer
+// template, in Unix milliseconds.
+type TemplateParameterLimitedTimeOffer struct {
+	ExpirationTimeMs int64 `json:"expiration_time_ms"`
 }
 
 func NewSendTemplateRequest(to, templateName, templateLang string, componentList []*TemplateComponent) *SendMessage {
@@ -82,6 +144,12 @@ func NewSendTemplateRequest(to, templateName, templateLang string, componentList
 	}
 }
 
+func NewSendContextTemplateRequest(to, templateName, templateLang, targetMessage string, componentList []*TemplateComponent) *SendMessage {
+	msg := NewSendTemplateRequest(to, templateName, templateLang, componentList)
+	msg.ContextMessage = &ContextMessage{Context: &Context{MessageId: targetMessage}}
+	return msg
+}
+
 func (s *SendMessage) validateTemplateMessage() error {
 	if s.TemplateMessage == nil {
 		return &errorsx.ValidationError{Op: "validateTemplateMessage", Field: "TemplateMessage", Reason: "nil body"}
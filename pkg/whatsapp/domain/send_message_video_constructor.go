@@ -6,8 +6,8 @@ type VideoMessage struct {
 	Video *VideoBody `json:"video"`
 }
 type VideoBody struct {
-	Id      *string `json:"id"`
-	Link    *string `json:"link"`
+	Id      *string `json:"id,omitempty"`
+	Link    *string `json:"link,omitempty"`
 	Caption string  `json:"caption"`
 }
 
@@ -64,11 +64,12 @@ func (s *SendMessage) validateVideoMessage() error {
 		return &errorsx.ValidationError{Field: "Video", Reason: "video is nil", Op: "validateVideoMessage"}
 	}
 
-	if *s.VideoMessage.Video.Id == "" && *s.VideoMessage.Video.Link == "" {
+	hasID, hasLink := hasReference(s.VideoMessage.Video.Id, s.VideoMessage.Video.Link)
+	if !hasID && !hasLink {
 		return &errorsx.ValidationError{Field: "Video", Reason: "the reference must be a link or a Id, nothing received", Op: "validateVideoMessage"}
 	}
 
-	if *s.VideoMessage.Video.Id != "" && *s.VideoMessage.Video.Link != "" {
+	if hasID && hasLink {
 		return &errorsx.ValidationError{Field: "Video", Reason: "the reference must be a link or a Id, both received", Op: "validateVideoMessage"}
 	}
 	return nil
@@ -0,0 +1,313 @@
+package domain
+
+import (
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Button sub types of template button components.
+const (
+	TemplateButtonSubTypeQuickReply = "quick_reply"
+	TemplateButtonSubTypeURL        = "url"
+	TemplateButtonSubTypeCopyCode   = "copy_code"
+	TemplateButtonSubTypeFlow       = "flow"
+	TemplateButtonSubTypeCatalog    = "catalog"
+	TemplateButtonSubTypeMPM        = "mpm"
+)
+
+// TemplateBuilder assembles the components of a template message fluently:
+//
+//	msg := NewTemplateBuilder("order_update", "en_US").
+//		HeaderImage("https://example.com/box.png").
+//		BodyText("Ana", "Monday").
+//		URLButton(0, "42").
+//		Message("5511999999999")
+//
+// Components are emitted in the order the API documents (header, body,
+// limited-time offer, carousel, buttons by index), regardless of call order.
+// The builder does not validate; use SendMessage.Validate or a TemplateValidator.
+type TemplateBuilder struct {
+	name     string
+	language string
+
+	header   *TemplateComponent
+	body     *TemplateComponent
+	offer    *TemplateComponent
+	carousel *TemplateComponent
+	buttons  []*TemplateComponent
+}
+
+func NewTemplateBuilder(name, language string) *TemplateBuilder {
+	return &TemplateBuilder{name: name, language: language}
+}
+
+// Header sets the header parameters, replacing any previous header.
+func (b *TemplateBuilder) Header(params ...*TemplateParameter) *TemplateBuilder {
+	b.header = &TemplateComponent{Type: "header", Parameters: params}
+	return b
+}
+
+// HeaderText fills the single variable of a TEXT header.
+func (b *TemplateBuilder) HeaderText(text string) *TemplateBuilder {
+	return b.Header(NewTemplateTextParameter(text))
+}
+
+// HeaderNamedText fills the single {{name}} variable of a TEXT header.
+func (b *TemplateBuilder) HeaderNamedText(name, text string) *TemplateBuilder {
+	return b.Header(NewTemplateNamedTextParameter(name, text))
+}
+
+func (b *TemplateBuilder) HeaderImage(link string) *TemplateBuilder {
+	return b.Header(NewTemplateImageParameter(link))
+}
+
+func (b *TemplateBuilder) HeaderVideo(link string) *TemplateBuilder {
+	return b.Header(NewTemplateVideoParameter(link))
+}
+
+func (b *TemplateBuilder) HeaderDocument(link, filename string) *TemplateBuilder {
+	return b.Header(NewTemplateDocumentParameter(link, filename))
+}
+
+func (b *TemplateBuilder) HeaderLocation(latitude, longitude float64, name, address string) *TemplateBuilder {
+	return b.Header(NewTemplateLocationParameter(latitude, longitude, name, address))
+}
+
+// Body appends parameters to the body component.
+func (b *TemplateBuilder) Body(params ...*TemplateParameter) *TemplateBuilder {
+	b.body = appendParameters(b.body, "body", params)
+	return b
+}
+
+// BodyText appends positional text parameters ({{1}}, {{2}}, ...) in order.
+func (b *TemplateBuilder) BodyText(values ...string) *TemplateBuilder {
+	for _, v := range values {
+		b.Body(NewTemplateTextParameter(v))
+	}
+	return b
+}
+
+// BodyNamed appends the value of a {{name}} variable of a NAMED template.
+func (b *TemplateBuilder) BodyNamed(name, value string) *TemplateBuilder {
+	return b.Body(NewTemplateNamedTextParameter(name, value))
+}
+
+// Button sets the parameters of the button at index, replacing a previous
+// button with the same index.
+func (b *TemplateBuilder) Button(index int, subType string, params ...*TemplateParameter) *TemplateBuilder {
+	b.buttons = setButton(b.buttons, index, subType, params)
+	return b
+}
+
+// QuickReplyButton sets the payload echoed back in the webhook when the button is tapped.
+func (b *TemplateBuilder) QuickReplyButton(index int, payload string) *TemplateBuilder {
+	return b.Button(index, TemplateButtonSubTypeQuickReply, NewTemplatePayloadParameter(payload))
+}
+
+// URLButton sets the dynamic suffix of a URL button.
+func (b *TemplateBuilder) URLButton(index int, suffix string) *TemplateBuilder {
+	return b.Button(index, TemplateButtonSubTypeURL, NewTemplateTextParameter(suffix))
+}
+
+// CopyCodeButton sets the coupon code copied by a COPY_CODE button.
+func (b *TemplateBuilder) CopyCodeButton(index int, code string) *TemplateBuilder {
+	return b.Button(index, TemplateButtonSubTypeCopyCode, NewTemplateCouponCodeParameter(code))
+}
+
+// OTPButton sets the one-time password of an authentication template button
+// (copy-code, one-tap or zero-tap). The API addresses it as a url button.
+func (b *TemplateBuilder) OTPButton(index int, code string) *TemplateBuilder {
+	return b.Button(index, TemplateButtonSubTypeURL, NewTemplateTextParameter(code))
+}
+
+// FlowButton sets the flow token and optional initial data of a FLOW button.
+func (b *TemplateBuilder) FlowButton(index int, flowToken string, data map[string]any) *TemplateBuilder {
+	return b.Button(index, TemplateButtonSubTypeFlow, NewTemplateActionParameter(&TemplateParameterAction{FlowToken: flowToken, FlowActionData: data}))
+}
+
+// CatalogButton sets the product used as thumbnail of a CATALOG button.
+func (b *TemplateBuilder) CatalogButton(index int, thumbnailProductRetailerID string) *TemplateBuilder {
+	return b.Button(index, TemplateButtonSubTypeCatalog, NewTemplateActionParameter(&TemplateParameterAction{ThumbnailProductRetailerID: thumbnailProductRetailerID}))
+}
+
+// LimitedTimeOffer sets the offer expiration of a limited-time-offer template.
+func (b *TemplateBuilder) LimitedTimeOffer(expiresAt time.Time) *TemplateBuilder {
+	b.offer = &TemplateComponent{Type: "limited_time_offer", Parameters: []*TemplateParameter{{
+		Type:             "limited_time_offer",
+		LimitedTimeOffer: &TemplateParameterLimitedTimeOffer{ExpirationTimeMs: expiresAt.UnixMilli()},
+	}}}
+	return b
+}
+
+// Carousel sets the cards of a carousel template.
+func (b *TemplateBuilder) Carousel(cards ...*TemplateCardBuilder) *TemplateBuilder {
+	c := &TemplateComponent{Type: "carousel"}
+	for _, card := range cards {
+		c.Cards = append(c.Cards, card.Build())
+	}
+	b.carousel = c
+	return b
+}
+
+// Components returns the components in API order.
+func (b *TemplateBuilder) Components() []*TemplateComponent {
+	return collectComponents(b.header, b.body, b.offer, b.carousel, b.buttons)
+}
+
+// Build returns the template in the shape used by SendMessage.
+func (b *TemplateBuilder) Build() *TemplateMessage {
+	return &TemplateMessage{Template: &TemplateBody{
+		Name:       b.name,
+		Language:   &TemplateLanguage{Code: b.language},
+		Components: b.Components(),
+	}}
+}
+
+// Message returns a ready-to-send template message to the given recipient.
+func (b *TemplateBuilder) Message(to string) *SendMessage {
+	return NewSendTemplateRequest(to, b.name, b.language, b.Components())
+}
+
+// TemplateCardBuilder assembles one card of a carousel template.
+type TemplateCardBuilder struct {
+	index   int
+	header  *TemplateComponent
+	body    *TemplateComponent
+	buttons []*TemplateComponent
+}
+
+func NewTemplateCardBuilder(cardIndex int) *TemplateCardBuilder {
+	return &TemplateCardBuilder{index: cardIndex}
+}
+
+func (c *TemplateCardBuilder) Header(params ...*TemplateParameter) *TemplateCardBuilder {
+	c.header = &TemplateComponent{Type: "header", Parameters: params}
+	return c
+}
+
+func (c *TemplateCardBuilder) HeaderImage(link string) *TemplateCardBuilder {
+	return c.Header(NewTemplateImageParameter(link))
+}
+
+func (c *TemplateCardBuilder) HeaderVideo(link string) *TemplateCardBuilder {
+	return c.Header(NewTemplateVideoParameter(link))
+}
+
+func (c *TemplateCardBuilder) Body(params ...*TemplateParameter) *TemplateCardBuilder {
+	c.body = appendParameters(c.body, "body", params)
+	return c
+}
+
+func (c *TemplateCardBuilder) BodyText(values ...string) *TemplateCardBuilder {
+	for _, v := range values {
+		c.Body(NewTemplateTextParameter(v))
+	}
+	return c
+}
+
+func (c *TemplateCardBuilder) Button(index int, subType string, params ...*TemplateParameter) *TemplateCardBuilder {
+	c.buttons = setButton(c.buttons, index, subType, params)
+	return c
+}
+
+func (c *TemplateCardBuilder) QuickReplyButton(index int, payload string) *TemplateCardBuilder {
+	return c.Button(index, TemplateButtonSubTypeQuickReply, NewTemplatePayloadParameter(payload))
+}
+
+func (c *TemplateCardBuilder) URLButton(index int, suffix string) *TemplateCardBuilder {
+	return c.Button(index, TemplateButtonSubTypeURL, NewTemplateTextParameter(suffix))
+}
+
+func (c *TemplateCardBuilder) Build() *TemplateCard {
+	return &TemplateCard{CardIndex: c.index, Components: collectComponents(c.header, c.body, nil, nil, c.buttons)}
+}
+
+func NewTemplateTextParameter(text string) *TemplateParameter {
+	return &TemplateParameter{Type: "text", Text: &text}
+}
+
+func NewTemplateNamedTextParameter(name, text string) *TemplateParameter {
+	return &TemplateParameter{Type: "text", ParameterName: name, Text: &text}
+}
+
+func NewTemplateCurrencyParameter(fallback, code string, amount1000 int) *TemplateParameter {
+	return &TemplateParameter{Type: "currency", TemplateParameterCurrency: &TemplateParameterCurrency{
+		Currency: &TemplateParameterCurrencyOptions{FallbackValue: fallback, Code: code, Amount1000: amount1000},
+	}}
+}
+
+func NewTemplateDateTimeParameter(fallback string) *TemplateParameter {
+	return &TemplateParameter{Type: "date_time", TemplateParameterDateTime: &TemplateParameterDateTime{
+		DateTime: &TemplateParameterDateTimeOptions{FallbackValue: fallback},
+	}}
+}
+
+func NewTemplateImageParameter(link string) *TemplateParameter {
+	return &TemplateParameter{Type: "image", TemplateParameterImage: &TemplateParameterImage{Image: &TemplateParameterImageOptions{Link: link}}}
+}
+
+func NewTemplateVideoParameter(link string) *TemplateParameter {
+	return &TemplateParameter{Type: "video", TemplateParameterVideo: &TemplateParameterVideo{Video: &TemplateParameterVideoOptions{Link: link}}}
+}
+
+func NewTemplateDocumentParameter(link, filename string) *TemplateParameter {
+	return &TemplateParameter{Type: "document", TemplateParameterDocument: &TemplateParameterDocument{
+		Document: &TemplateParameterDocumentOptions{Link: link, Filename: filename},
+	}}
+}
+
+func NewTemplateLocationParameter(latitude, longitude float64, name, address string) *TemplateParameter {
+	return &TemplateParameter{Type: "location", TemplateParameterLocation: &TemplateParameterLocation{
+		Location: &TemplateParameterLocationOptions{Latitude: latitude, Longitude: longitude, Name: name, Address: address},
+	}}
+}
+
+func NewTemplatePayloadParameter(payload string) *TemplateParameter {
+	return &TemplateParameter{Type: "payload", Payload: &payload}
+}
+
+func NewTemplateCouponCodeParameter(code string) *TemplateParameter {
+	return &TemplateParameter{Type: "coupon_code", CouponCode: &code}
+}
+
+func NewTemplateActionParameter(action *TemplateParameterAction) *TemplateParameter {
+	return &TemplateParameter{Type: "action", Action: action}
+}
+
+func appendParameters(c *TemplateComponent, kind string, params []*TemplateParameter) *TemplateComponent {
+	if c == nil {
+		c = &TemplateComponent{Type: kind}
+	}
+	c.Parameters = append(c.Parameters, params...)
+	return c
+}
+
+func setButton(buttons []*TemplateComponent, index int, subType string, params []*TemplateParameter) []*TemplateComponent {
+	idx := strconv.Itoa(index)
+	st := subType
+	btn := &TemplateComponent{Type: "button", SubType: &st, Index: &idx, Parameters: params}
+	for i, existing := range buttons {
+		if *existing.Index == idx {
+			buttons[i] = btn
+			return buttons
+		}
+	}
+	buttons = append(buttons, btn)
+	sort.SliceStable(buttons, func(i, j int) bool {
+		a, _ := strconv.Atoi(*buttons[i].Index)
+		b, _ := strconv.Atoi(*buttons[j].Index)
+		return a < b
+	})
+	return buttons
+}
+
+func collectComponents(header, body, offer, carousel *TemplateComponent, buttons []*TemplateComponent) []*TemplateComponent {
+	var out []*TemplateComponent
+	for _, c := range []*TemplateComponent{header, body, offer, carousel} {
+		if c != nil {
+			out = append(out, c)
+		}
+	}
+	return append(out, buttons...)
+}
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTemplateBuilder_RoundTrip(t *testing.T) {
+	expires := time.UnixMilli(1767225600000)
+	msg := NewTemplateBuilder("summer_sale", "pt_BR").
+		CopyCodeButton(1, "SUMMER25").
+		URLButton(0, "promo/42").
+		BodyNamed("first_name", "Ana").
+		Body(NewTemplateCurrencyParameter("R$10,00", "BRL", 10000)).
+		HeaderVideo("https://example.com/promo.mp4").
+		LimitedTimeOffer(expires).
+		Message("5511999999999")
+
+	if err := msg.Validate(); err != nil {
+		t.Fatalf("Validate error: %v", err)
+	}
+
+	want := `{"name":"summer_sale","language":{"code":"pt_BR"},"components":[` +
+		`{"type":"header","parameters":[{"type":"video","video":{"link":"https://example.com/promo.mp4"}}]},` +
+		`{"type":"body","parameters":[{"type":"text","parameter_name":"first_name","text":"Ana"},{"type":"currency","currency":{"fallback_value":"R$10,00","code":"BRL","amount_1000":10000}}]},` +
+		`{"type":"limited_time_offer","parameters":[{"type":"limited_time_offer","limited_time_offer":{"expiration_time_ms":1767225600000}}]},` +
+		`{"type":"button","sub_type":"url","index":"0","parameters":[{"type":"text","text":"promo/42"}]},` +
+		`{"type":"button","sub_type":"copy_code","index":"1","parameters":[{"type":"coupon_code","coupon_code":"SUMMER25"}]}]}`
+
+	b, err := json.Marshal(msg.TemplateMessage.Template)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if string(b) != want {
+		t.Fatalf("unexpected JSON:\n got %s\nwant %s", b, want)
+	}
+
+	var back TemplateBody
+	if err := json.Unmarshal(b, &back); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(&back, msg.TemplateMessage.Template) {
+		t.Fatalf("round trip mismatch:\n got %+v\nwant %+v", back, *msg.TemplateMessage.Template)
+	}
+}
+
+func TestTemplateBuilder_CarouselAndActions(t *testing.T) {
+	tm := NewTemplateBuilder("catalog_cards", "en_US").
+		BodyText("Ana").
+		Carousel(
+			NewTemplateCardBuilder(0).HeaderImage("https://example.com/a.png").BodyText("A").QuickReplyButton(0, "more-a"),
+			NewTemplateCardBuilder(1).HeaderVideo("https://example.com/b.mp4").URLButton(0, "b"),
+		).
+		FlowButton(0, "tok", map[string]any{"step": "1"}).
+		Build()
+
+	b, err := json.Marshal(tm)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var back TemplateMessage
+	if err := json.Unmarshal(b, &back); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(&back, tm) {
+		t.Fatalf("round trip mismatch: %s", b)
+	}
+
+	comps := back.Template.Components
+	if len(comps) != 3 || comps[1].Type != "carousel" || comps[2].Type != "button" {
+		t.Fatalf("unexpected component order: %s", b)
+	}
+	if cards := comps[1].Cards; len(cards) != 2 || cards[1].CardIndex != 1 || len(cards[1].Components) != 2 {
+		t.Fatalf("unexpected cards: %s", b)
+	}
+	if a := comps[2].Parameters[0].Action; a == nil || a.FlowToken != "tok" || a.FlowActionData["step"] != "1" {
+		t.Fatalf("flow action not encoded: %s", b)
+	}
+}
+
+func TestTemplateBuilder_ReplacesButtonIndex(t *testing.T) {
+	comps := NewTemplateBuilder("t", "en").
+		QuickReplyButton(0, "a").
+		QuickReplyButton(0, "b").
+		OTPButton(1, "123456").
+		Components()
+	if len(comps) != 2 || *comps[0].Parameters[0].Payload != "b" {
+		t.Fatalf("button index 0 not replaced: %+v", comps)
+	}
+	if *comps[1].SubType != TemplateButtonSubTypeURL || *comps[1].Parameters[0].Text != "123456" {
+		t.Fatalf("unexpected OTP button: %+v", comps[1])
+	}
+}
+
+func TestTemplateBuilder_ValidateSend(t *testing.T) {
+	def := &MessageTemplate{
+		Name: "welcome", Language: "en", ParameterFormat: TemplateParameterFormatNamed,
+		Components: []MessageTemplateComponent{
+			{Type: TemplateComponentHeader, Format: TemplateHeaderFormatDocument},
+			{Type: TemplateComponentBody, Text: "Hi {{first_name}}"},
+			{Type: TemplateComponentButtons, Buttons: []MessageTemplateButton{{Type: "COPY_CODE", Example: json.RawMessage(`"ABC"`)}}},
+		},
+	}
+	tm := NewTemplateBuilder("welcome", "en").
+		HeaderDocument("https://example.com/a.pdf", "a.pdf").
+		BodyNamed("first_name", "Ana").
+		CopyCodeButton(0, "WELCOME10").
+		Build()
+	if err := def.ValidateSend(tm.Template); err != nil {
+		t.Fatalf("ValidateSend error: %v", err)
+	}
+}
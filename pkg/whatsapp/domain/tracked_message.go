@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+// Values of MessageStatus.Status.
+const (
+	MessageStatusSent      = "sent"
+	MessageStatusDelivered = "delivered"
+	MessageStatusRead      = "read"
+	MessageStatusFailed    = "failed"
+)
+
+// StatusRank orders statuses by progress: sent < delivered < read, with failed
+// above all of them as it is terminal. Unknown statuses rank 0.
+func StatusRank(status string) int {
+	switch status {
+	case MessageStatusSent:
+		return 1
+	case MessageStatusDelivered:
+		return 2
+	case MessageStatusRead:
+		return 3
+	case MessageStatusFailed:
+		return 4
+	}
+	return 0
+}
+
+// TrackedMessage is the delivery state of an outbound message, combining the
+// send response with the statuses later received via webhook.
+type TrackedMessage struct {
+	WAMID       string `json:"wamid"`
+	RecipientID string `json:"recipient_id,omitempty"`
+	// Status is the most advanced status seen so far; statuses arriving out of
+	// order never move it back. Empty until the first status arrives.
+	Status string         `json:"status,omitempty"`
+	Errors []WebhookError `json:"errors,omitempty"` // set when Status is failed
+	// Timestamps holds the webhook timestamp (unix seconds) of each status seen.
+	Timestamps map[string]string `json:"timestamps,omitempty"`
+	// Tracked is false when statuses arrived before the send was recorded.
+	Tracked   bool      `json:"tracked"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
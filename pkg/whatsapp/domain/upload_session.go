@@ -0,0 +1,14 @@
+package domain
+
+// UploadSession is a Resumable Upload API session (POST /{App-ID}/uploads and
+// GET /{Upload-Session-ID}). FileOffset is how many bytes the server already has.
+type UploadSession struct {
+	ID         string `json:"id"`
+	FileOffset int64  `json:"file_offset"`
+}
+
+// UploadHandle is returned once the whole file was received. H is the handle used
+// as template header sample (example.header_handle) or profile picture handle.
+type UploadHandle struct {
+	H string `json:"h"`
+}
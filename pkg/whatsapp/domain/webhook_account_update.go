@@ -0,0 +1,45 @@
+package domain
+
+// AccountUpdate is the value of account_update: verification, bans,
+// violations, restrictions and partner changes of the business account.
+type AccountUpdate struct {
+	PhoneNumber     string               `json:"phone_number,omitempty"`
+	Event           string               `json:"event"` // e.g. "VERIFIED_ACCOUNT", "DISABLED_UPDATE", "ACCOUNT_VIOLATION"
+	BanInfo         *AccountBanInfo      `json:"ban_info,omitempty"`
+	ViolationInfo   *AccountViolation    `json:"violation_info,omitempty"`
+	RestrictionInfo []AccountRestriction `json:"restriction_info,omitempty"`
+	WABAInfo        *AccountWABAInfo     `json:"waba_info,omitempty"`
+}
+
+type AccountBanInfo struct {
+	WABABanState []string `json:"waba_ban_state,omitempty"`
+	WABABanDate  string   `json:"waba_ban_date,omitempty"`
+}
+
+type AccountViolation struct {
+	ViolationType string `json:"violation_type"`
+}
+
+type AccountRestriction struct {
+	RestrictionType string `json:"restriction_type"`
+	Expiration      string `json:"expiration,omitempty"`
+}
+
+type AccountWABAInfo struct {
+	WABAID          string `json:"waba_id"`
+	OwnerBusinessID string `json:"owner_business_id,omitempty"`
+	PartnerAppID    string `json:"partner_app_id,omitempty"`
+}
+
+// AccountReviewUpdate is the value of account_review_update.
+type AccountReviewUpdate struct {
+	Decision string `json:"decision"` // "APPROVED", "REJECTED", "PENDING" or "DEFERRED"
+}
+
+// BusinessCapabilityUpdate is the value of business_capability_update, sent
+// when the account's messaging limits change.
+type BusinessCapabilityUpdate struct {
+	MaxDailyConversationPerPhone int `json:"max_daily_conversation_per_phone,omitempty"`
+	MaxPhoneNumbersPerBusiness   int `json:"max_phone_numbers_per_business,omitempty"`
+	MaxPhoneNumbersPerWABA       int `json:"max_phone_numbers_per_waba,omitempty"`
+}
@@ -1,6 +1,78 @@
 package domain
 
+import "encoding/json"
+
 type WebhookChange struct {
 	Field string       `json:"field"`
 	Value WebhookValue `json:"value"`
+	// Update is the value decoded by Field for non-message fields: a pointer
+	// to TemplateStatusUpdate, AccountUpdate, ... (see WebhookField*). It is
+	// nil for "messages" and for fields this package does not know.
+	Update any `json:"-"`
+	// Raw holds the value of a known field that did not match its type, in
+	// which case Update is nil. Meta occasionally changes payload shapes; one
+	// odd change must not make the whole event unreadable.
+	Raw json.RawMessage `json:"-"`
+}
+
+func (c *WebhookChange) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Field string          `json:"field"`
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*c = WebhookChange{Field: raw.Field}
+	if len(raw.Value) == 0 {
+		return nil
+	}
+	newUpdate, typed := webhookFieldTypes[raw.Field]
+	if !typed {
+		return json.Unmarshal(raw.Value, &c.Value)
+	}
+	u := newUpdate()
+	if err := json.Unmarshal(raw.Value, u); err != nil {
+		c.Raw = raw.Value
+		return nil
+	}
+	c.Update = u
+	return nil
+}
+
+// Metadata returns the business phone number the change is for: from the
+// typed Update when there is one, from Raw for a mistyped value, and from Value
+// otherwise. It is nil for account-level fields.
+func (c *WebhookChange) Metadata() *WebhookMetadata {
+	switch {
+	case c.Update != nil:
+		if u, ok := c.Update.(interface{ metadata() *WebhookMetadata }); ok {
+			return u.metadata()
+		}
+		return nil
+	case c.Raw != nil:
+		var v struct {
+			Metadata *WebhookMetadata `json:"metadata"`
+		}
+		_ = json.Unmarshal(c.Raw, &v)
+		return v.Metadata
+	}
+	return c.Value.Metadata
+}
+
+func (c WebhookChange) MarshalJSON() ([]byte, error) {
+	if c.Update != nil {
+		return json.Marshal(struct {
+			Field string `json:"field"`
+			Value any    `json:"value"`
+		}{c.Field, c.Update})
+	}
+	if c.Raw != nil {
+		return json.Marshal(struct {
+			Field string          `json:"field"`
+			Value json.RawMessage `json:"value"`
+		}{c.Field, c.Raw})
+	}
+	type plain WebhookChange
+	return json.Marshal(plain(c))
 }
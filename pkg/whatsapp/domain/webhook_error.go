@@ -1,7 +1,34 @@
 package domain
 
+import (
+	"fmt"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/errorsx"
+)
+
 type WebhookError struct {
-	Code    int    `json:"code"`
-	Title   string `json:"title"`
-	Message string `json:"message"`
+	Code      int               `json:"code"`
+	Title     string            `json:"title"`
+	Message   string            `json:"message"`
+	ErrorData *WebhookErrorData `json:"error_data,omitempty"`
+	Href      string            `json:"href,omitempty"`
+}
+
+type WebhookErrorData struct {
+	Details string `json:"details"`
+}
+
+// Error implements the error interface, so a failed status can be returned and
+// matched with errors.Is against the errorsx code catalog.
+func (e *WebhookError) Error() string {
+	if e.ErrorData != nil && e.ErrorData.Details != "" {
+		return fmt.Sprintf("webhook error %d: %s: %s", e.Code, e.Title, e.ErrorData.Details)
+	}
+	return fmt.Sprintf("webhook error %d: %s", e.Code, e.Title)
+}
+
+// Is reports whether target is the errorsx catalog sentinel for e.Code.
+func (e *WebhookError) Is(target error) bool {
+	ce, ok := target.(*errorsx.CodeError)
+	return ok && ce.Code == e.Code
 }
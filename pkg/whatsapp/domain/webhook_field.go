@@ -0,0 +1,31 @@
+package domain
+
+// Webhook fields as delivered in WebhookChange.Field. Changes of every field
+// but WebhookFieldMessages carry a typed WebhookChange.Update.
+const (
+	WebhookFieldMessages                 = "messages"
+	WebhookFieldTemplateStatusUpdate     = "message_template_status_update"
+	WebhookFieldTemplateQualityUpdate    = "message_template_quality_update"
+	WebhookFieldTemplateCategoryUpdate   = "template_category_update"
+	WebhookFieldPhoneNumberQualityUpdate = "phone_number_quality_update"
+	WebhookFieldPhoneNumberNameUpdate    = "phone_number_name_update"
+	WebhookFieldAccountUpdate            = "account_update"
+	WebhookFieldAccountReviewUpdate      = "account_review_update"
+	WebhookFieldBusinessCapabilityUpdate = "business_capability_update"
+	WebhookFieldSecurity                 = "security"
+	WebhookFieldUserPreferences          = "user_preferences"
+)
+
+// webhookFieldTypes creates the typed value of each non-message field.
+var webhookFieldTypes = map[string]func() any{
+	WebhookFieldTemplateStatusUpdate:     func() any { return new(TemplateStatusUpdate) },
+	WebhookFieldTemplateQualityUpdate:    func() any { return new(TemplateQualityUpdate) },
+	WebhookFieldTemplateCategoryUpdate:   func() any { return new(TemplateCategoryUpdate) },
+	WebhookFieldPhoneNumberQualityUpdate: func() any { return new(PhoneNumberQualityUpdate) },
+	WebhookFieldPhoneNumberNameUpdate:    func() any { return new(PhoneNumberNameUpdate) },
+	WebhookFieldAccountUpdate:            func() any { return new(AccountUpdate) },
+	WebhookFieldAccountReviewUpdate:      func() any { return new(AccountReviewUpdate) },
+	WebhookFieldBusinessCapabilityUpdate: func() any { return new(BusinessCapabilityUpdate) },
+	WebhookFieldSecurity:                 func() any { return new(SecurityUpdate) },
+	WebhookFieldUserPreferences:          func() any { return new(UserPreferencesUpdate) },
+}
@@ -4,16 +4,25 @@ package domain
 // Message types are additive; only the fields for the concrete message type will be present.
 // Extend with other message types as needed using optional pointers.
 type InboundMessage struct {
-	ID          string             `json:"id"`
-	From        string             `json:"from"`
-	Timestamp   string             `json:"timestamp"`
-	Type        string             `json:"type"`
-	Text        *MessageText       `json:"text,omitempty"`
-	Image       *MediaObject       `json:"image,omitempty"`
-	Document    *MediaObject       `json:"document,omitempty"`
-	Audio       *MediaObject       `json:"audio,omitempty"`
-	Video       *MediaObject       `json:"video,omitempty"`
-	Sticker     *MediaObject       `json:"sticker,omitempty"`
-	Interactive *InteractiveObject `json:"interactive,omitempty"`
-	Context     *MessageContext    `json:"context,omitempty"`
+	ID          string              `json:"id"`
+	From        string              `json:"from"`
+	Timestamp   string              `json:"timestamp"`
+	Type        string              `json:"type"`
+	Text        *MessageText        `json:"text,omitempty"`
+	Image       *MediaObject        `json:"image,omitempty"`
+	Document    *MediaObject        `json:"document,omitempty"`
+	Audio       *MediaObject        `json:"audio,omitempty"`
+	Video       *MediaObject        `json:"video,omitempty"`
+	Sticker     *MediaObject        `json:"sticker,omitempty"`
+	Interactive *InteractiveObject  `json:"interactive,omitempty"`
+	Button      *QuickReplyButton   `json:"button,omitempty"`
+	Reaction    *InboundReaction    `json:"reaction,omitempty"`
+	Location    *InboundLocation    `json:"location,omitempty"`
+	Contacts    []Contact           `json:"contacts,omitempty"`
+	Order       *InboundOrder       `json:"order,omitempty"`
+	System      *InboundSystem      `json:"system,omitempty"`
+	Referral    *Referral           `json:"referral,omitempty"`
+	Unsupported *InboundUnsupported `json:"unsupported,omitempty"`
+	Errors      []WebhookError      `json:"errors,omitempty"`
+	Context     *MessageContext     `json:"context,omitempty"`
 }
@@ -1,7 +1,45 @@
 package domain
 
+// InteractiveObject is the user's answer to an interactive message. Type tells
+// which reply is populated: "button_reply", "list_reply" or "nfm_reply" (Flows).
 type InteractiveObject struct {
-	Type string `json:"type"`
-	// Add subtypes as needed, e.g., button replies or list replies
-	// For example: "button" replies emit a nested structure with id/title.
+	Type        string                  `json:"type"`
+	ButtonReply *InteractiveButtonReply `json:"button_reply,omitempty"`
+	ListReply   *InteractiveListReply   `json:"list_reply,omitempty"`
+	NFMReply    *InteractiveNFMReply    `json:"nfm_reply,omitempty"`
+}
+
+// InteractiveButtonReply carries the id/title of the tapped reply button.
+type InteractiveButtonReply struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// InteractiveListReply carries the id/title/description of the selected list row.
+type InteractiveListReply struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+}
+
+// InteractiveNFMReply is the completion payload of a WhatsApp Flow. ResponseJSON
+// is a JSON document encoded as a string, exactly as delivered by the API.
+type InteractiveNFMReply struct {
+	Name         string `json:"name,omitempty"`
+	Body         string `json:"body,omitempty"`
+	ResponseJSON string `json:"response_json"`
+}
+
+// ReplyID returns the id of the selected button or list row, or "" for other replies.
+func (o *InteractiveObject) ReplyID() string {
+	if o == nil {
+		return ""
+	}
+	switch {
+	case o.ButtonReply != nil:
+		return o.ButtonReply.ID
+	case o.ListReply != nil:
+		return o.ListReply.ID
+	}
+	return ""
 }
@@ -6,4 +6,24 @@ type MediaObject struct {
 	SHA256   string  `json:"sha256,omitempty"`
 	Caption  *string `json:"caption,omitempty"`
 	Filename *string `json:"filename,omitempty"`
+	Voice    bool    `json:"voice,omitempty"`    // audio recorded as a voice note
+	Animated bool    `json:"animated,omitempty"` // animated sticker
+}
+
+// Media returns the media object of image, audio, video, document and sticker
+// messages, or nil for any other type.
+func (m InboundMessage) Media() *MediaObject {
+	switch m.Type {
+	case MessageTypeImage:
+		return m.Image
+	case MessageTypeAudio:
+		return m.Audio
+	case MessageTypeVideo:
+		return m.Video
+	case MessageTypeDocument:
+		return m.Document
+	case MessageTypeSticker:
+		return m.Sticker
+	}
+	return nil
 }
@@ -1,6 +1,17 @@
 package domain
 
+// MessageContext is present when the user replies to, forwards, or asks about a
+// product from a previous message.
 type MessageContext struct {
-	From string `json:"from,omitempty"`
-	ID   string `json:"id,omitempty"`
+	From                string           `json:"from,omitempty"`
+	ID                  string           `json:"id,omitempty"`
+	Forwarded           bool             `json:"forwarded,omitempty"`
+	FrequentlyForwarded bool             `json:"frequently_forwarded,omitempty"`
+	ReferredProduct     *ReferredProduct `json:"referred_product,omitempty"`
+}
+
+// ReferredProduct identifies the catalog item the user asked about.
+type ReferredProduct struct {
+	CatalogID         string `json:"catalog_id"`
+	ProductRetailerID string `json:"product_retailer_id"`
 }
@@ -0,0 +1,27 @@
+package domain
+
+// Inbound message types as delivered in InboundMessage.Type.
+const (
+	MessageTypeText        = "text"
+	MessageTypeImage       = "image"
+	MessageTypeAudio       = "audio"
+	MessageTypeVideo       = "video"
+	MessageTypeDocument    = "document"
+	MessageTypeSticker     = "sticker"
+	MessageTypeInteractive = "interactive"
+	MessageTypeButton      = "button"
+	MessageTypeReaction    = "reaction"
+	MessageTypeLocation    = "location"
+	MessageTypeContacts    = "contacts"
+	MessageTypeOrder       = "order"
+	MessageTypeSystem      = "system"
+	MessageTypeUnsupported = "unsupported"
+	MessageTypeUnknown     = "unknown"
+)
+
+// Interactive reply types as delivered in InteractiveObject.Type.
+const (
+	InteractiveReplyTypeButton = "button_reply"
+	InteractiveReplyTypeList   = "list_reply"
+	InteractiveReplyTypeNFM    = "nfm_reply"
+)
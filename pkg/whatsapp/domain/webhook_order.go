@@ -0,0 +1,19 @@
+package domain
+
+import "encoding/json"
+
+// InboundOrder is a cart sent from a catalog or product message.
+type InboundOrder struct {
+	CatalogID    string             `json:"catalog_id"`
+	Text         string             `json:"text,omitempty"`
+	ProductItems []OrderProductItem `json:"product_items"`
+}
+
+// OrderProductItem is a single line of an order. Quantity and ItemPrice are kept
+// as json.Number because the API has delivered them both as numbers and strings.
+type OrderProductItem struct {
+	ProductRetailerID string      `json:"product_retailer_id"`
+	Quantity          json.Number `json:"quantity"`
+	ItemPrice         json.Number `json:"item_price"`
+	Currency          string      `json:"currency"`
+}
@@ -0,0 +1,20 @@
+package domain
+
+// PhoneNumberQualityUpdate is the value of phone_number_quality_update: the
+// number was flagged or its messaging limit changed.
+type PhoneNumberQualityUpdate struct {
+	DisplayPhoneNumber           string `json:"display_phone_number"`
+	Event                        string `json:"event"`         // e.g. "FLAGGED", "UNFLAGGED", "UPGRADE", "DOWNGRADE"
+	CurrentLimit                 string `json:"current_limit"` // e.g. "TIER_1K"
+	OldLimit                     string `json:"old_limit,omitempty"`
+	MaxDailyConversationPerPhone int    `json:"max_daily_conversation_per_phone,omitempty"`
+}
+
+// PhoneNumberNameUpdate is the value of phone_number_name_update, the review
+// result of a requested display name.
+type PhoneNumberNameUpdate struct {
+	DisplayPhoneNumber    string `json:"display_phone_number"`
+	Decision              string `json:"decision"` // "APPROVED", "REJECTED" or "DEFERRED"
+	RequestedVerifiedName string `json:"requested_verified_name"`
+	RejectionReason       string `json:"rejection_reason,omitempty"`
+}
@@ -0,0 +1,8 @@
+package domain
+
+// QuickReplyButton is sent when the user taps a quick-reply button of a template.
+// Payload is the developer-defined payload configured on the template button.
+type QuickReplyButton struct {
+	Payload string `json:"payload"`
+	Text    string `json:"text"`
+}
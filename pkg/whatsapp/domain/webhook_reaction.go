@@ -0,0 +1,8 @@
+package domain
+
+// InboundReaction is a reaction added to (or removed from, when Emoji is empty)
+// a previously sent message.
+type InboundReaction struct {
+	MessageID string `json:"message_id"`
+	Emoji     string `json:"emoji,omitempty"`
+}
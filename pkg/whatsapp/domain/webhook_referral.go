@@ -0,0 +1,16 @@
+package domain
+
+// Referral is attached to the first message a user sends after tapping a
+// Click-to-WhatsApp ad or a post. CtwaClid identifies the ad click.
+type Referral struct {
+	SourceURL    string `json:"source_url,omitempty"`
+	SourceID     string `json:"source_id,omitempty"`
+	SourceType   string `json:"source_type,omitempty"`
+	Headline     string `json:"headline,omitempty"`
+	Body         string `json:"body,omitempty"`
+	MediaType    string `json:"media_type,omitempty"`
+	ImageURL     string `json:"image_url,omitempty"`
+	VideoURL     string `json:"video_url,omitempty"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+	CtwaClid     string `json:"ctwa_clid,omitempty"`
+}
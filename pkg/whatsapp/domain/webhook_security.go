@@ -0,0 +1,9 @@
+package domain
+
+// SecurityUpdate is the value of security: two-step verification PIN changes
+// and reset requests for a phone number.
+type SecurityUpdate struct {
+	DisplayPhoneNumber string `json:"display_phone_number"`
+	Event              string `json:"event"` // e.g. "PIN_CHANGED", "PIN_RESET_REQUEST", "PIN_RESET_SUCCESS"
+	Requester          string `json:"requester,omitempty"`
+}
@@ -0,0 +1,12 @@
+package domain
+
+// InboundSystem notifies changes on the customer side, e.g. "user_changed_number"
+// (NewWaID is set) or "customer_identity_changed".
+type InboundSystem struct {
+	Body     string `json:"body,omitempty"`
+	Identity string `json:"identity,omitempty"`
+	NewWaID  string `json:"new_wa_id,omitempty"`
+	WaID     string `json:"wa_id,omitempty"`
+	Type     string `json:"type,omitempty"`
+	Customer string `json:"customer,omitempty"`
+}
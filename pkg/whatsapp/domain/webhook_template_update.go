@@ -0,0 +1,38 @@
+package domain
+
+// TemplateStatusUpdate is the value of message_template_status_update: a
+// template was approved, rejected, paused, disabled, ...
+type TemplateStatusUpdate struct {
+	Event                   string             `json:"event"` // e.g. "APPROVED", "REJECTED", "PAUSED"
+	MessageTemplateID       int64              `json:"message_template_id"`
+	MessageTemplateName     string             `json:"message_template_name"`
+	MessageTemplateLanguage string             `json:"message_template_language"`
+	Reason                  string             `json:"reason,omitempty"`
+	OtherInfo               *TemplateEventInfo `json:"other_info,omitempty"`
+}
+
+// TemplateEventInfo explains a pause or disable of a template.
+type TemplateEventInfo struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// TemplateQualityUpdate is the value of message_template_quality_update.
+type TemplateQualityUpdate struct {
+	PreviousQualityScore    string `json:"previous_quality_score"` // "GREEN", "YELLOW", "RED" or "UNKNOWN"
+	NewQualityScore         string `json:"new_quality_score"`
+	MessageTemplateID       int64  `json:"message_template_id"`
+	MessageTemplateName     string `json:"message_template_name"`
+	MessageTemplateLanguage string `json:"message_template_language"`
+}
+
+// TemplateCategoryUpdate is the value of template_category_update, sent when
+// Meta recategorizes a template.
+type TemplateCategoryUpdate struct {
+	MessageTemplateID       int64  `json:"message_template_id"`
+	MessageTemplateName     string `json:"message_template_name"`
+	MessageTemplateLanguage string `json:"message_template_language"`
+	PreviousCategory        string `json:"previous_category"`
+	NewCategory             string `json:"new_category"`
+	CorrectCategory         string `json:"correct_category,omitempty"`
+}
@@ -0,0 +1,7 @@
+package domain
+
+// InboundUnsupported describes a message type the Cloud API cannot deliver
+// (e.g. polls or view-once media). Details come in InboundMessage.Errors.
+type InboundUnsupported struct {
+	Type string `json:"type,omitempty"`
+}
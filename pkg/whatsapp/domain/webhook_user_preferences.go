@@ -0,0 +1,20 @@
+package domain
+
+// UserPreferencesUpdate is the value of user_preferences: users stopping or
+// resuming marketing messages.
+type UserPreferencesUpdate struct {
+	MessagingProduct string           `json:"messaging_product"`
+	Metadata         *WebhookMetadata `json:"metadata,omitempty"`
+	Contacts         []WebhookContact `json:"contacts,omitempty"`
+	UserPreferences  []UserPreference `json:"user_preferences"`
+}
+
+func (u *UserPreferencesUpdate) metadata() *WebhookMetadata { return u.Metadata }
+
+type UserPreference struct {
+	WaID      string `json:"wa_id"`
+	Detail    string `json:"detail,omitempty"`
+	Category  string `json:"category"` // e.g. "marketing_messages"
+	Value     string `json:"value"`    // "stop" or "resume"
+	Timestamp int64  `json:"timestamp"`
+}
@@ -1,6 +1,12 @@
 package domain
 
-import "testing"
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
 
 func TestParseWebhookEvent(t *testing.T) {
 	ok := []byte(`{"object":"whatsapp","entry":[]}`)
@@ -12,3 +18,200 @@ func TestParseWebhookEvent(t *testing.T) {
 		t.Fatalf("expected error for invalid json")
 	}
 }
+
+// loadWebhookFixture parses a webhook payload from testdata and returns its messages.
+func loadWebhookFixture(t *testing.T, name string) []InboundMessage {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join("..", "..", "..", "testdata", name))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	e, err := ParseWebhookEvent(b)
+	if err != nil {
+		t.Fatalf("ParseWebhookEvent: %v", err)
+	}
+	if len(e.Entry) != 1 || len(e.Entry[0].Changes) != 1 {
+		t.Fatalf("unexpected envelope: %+v", e)
+	}
+	msgs := e.Entry[0].Changes[0].Value.Messages
+	if len(msgs) == 0 {
+		t.Fatalf("fixture %s has no messages", name)
+	}
+	return msgs
+}
+
+func TestParseWebhookEvent_Interactive(t *testing.T) {
+	msgs := loadWebhookFixture(t, "webhook_messages_interactive.json")
+	if len(msgs) != 3 {
+		t.Fatalf("expected 3 messages, got %d", len(msgs))
+	}
+	btn := msgs[0]
+	if btn.Type != MessageTypeInteractive || btn.Interactive.Type != InteractiveReplyTypeButton {
+		t.Fatalf("unexpected button message: %+v", btn)
+	}
+	if btn.Interactive.ButtonReply == nil || btn.Interactive.ReplyID() != "confirm" || btn.Context == nil {
+		t.Fatalf("button_reply not decoded: %+v", btn.Interactive)
+	}
+	list := msgs[1].Interactive
+	if list.ListReply == nil || list.ReplyID() != "priority_express" || list.ListReply.Description == "" {
+		t.Fatalf("list_reply not decoded: %+v", list)
+	}
+	nfm := msgs[2].Interactive
+	if nfm.NFMReply == nil || !strings.Contains(nfm.NFMReply.ResponseJSON, "flow_token") || nfm.ReplyID() != "" {
+		t.Fatalf("nfm_reply not decoded: %+v", nfm)
+	}
+}
+
+func TestParseWebhookEvent_MessageTypes(t *testing.T) {
+	cases := []struct {
+		fixture string
+		check   func(m InboundMessage) bool
+	}{
+		{"webhook_messages_button.json", func(m InboundMessage) bool {
+			return m.Type == MessageTypeButton && m.Button != nil && m.Button.Payload == "STOP_PROMOTIONS"
+		}},
+		{"webhook_messages_reaction.json", func(m InboundMessage) bool {
+			return m.Type == MessageTypeReaction && m.Reaction != nil && m.Reaction.MessageID != "" && m.Reaction.Emoji != ""
+		}},
+		{"webhook_messages_location.json", func(m InboundMessage) bool {
+			return m.Type == MessageTypeLocation && m.Location != nil && m.Location.Latitude == 37.483307 && m.Location.Name == "Main Office"
+		}},
+		{"webhook_messages_contacts.json", func(m InboundMessage) bool {
+			return m.Type == MessageTypeContacts && len(m.Contacts) == 1 && m.Contacts[0].Name.FirstName == "Barbara" &&
+				m.Contacts[0].Phones[0].WaId == "19405551234"
+		}},
+		{"webhook_messages_order.json", func(m InboundMessage) bool {
+			if m.Type != MessageTypeOrder || m.Order == nil || len(m.Order.ProductItems) != 2 {
+				return false
+			}
+			q, err := m.Order.ProductItems[1].Quantity.Int64()
+			return err == nil && q == 1 && m.Order.ProductItems[0].ItemPrice.String() == "30"
+		}},
+		{"webhook_messages_system.json", func(m InboundMessage) bool {
+			return m.Type == MessageTypeSystem && m.System != nil && m.System.NewWaID == "16505554321"
+		}},
+		{"webhook_messages_referral.json", func(m InboundMessage) bool {
+			return m.Type == MessageTypeText && m.Referral != nil && m.Referral.SourceType == "ad" && m.Referral.CtwaClid != ""
+		}},
+		{"webhook_messages_unsupported.json", func(m InboundMessage) bool {
+			return m.Type == MessageTypeUnsupported && len(m.Errors) == 1 && m.Errors[0].Code == 131051 &&
+				m.Errors[0].ErrorData != nil && m.Errors[0].ErrorData.Details != "" && m.Unsupported.Type == "poll_creation"
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.fixture, func(t *testing.T) {
+			msgs := loadWebhookFixture(t, tc.fixture)
+			if !tc.check(msgs[0]) {
+				t.Fatalf("message not decoded as expected: %+v", msgs[0])
+			}
+		})
+	}
+}
+
+func TestParseWebhookEvent_Fields(t *testing.T) {
+	b, err := os.ReadFile(filepath.Join("..", "..", "..", "testdata", "webhook_fields.json"))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	e, err := ParseWebhookEvent(b)
+	if err != nil {
+		t.Fatalf("ParseWebhookEvent: %v", err)
+	}
+	changes := e.Entry[0].Changes
+	if len(changes) != len(webhookFieldTypes) {
+		t.Fatalf("expected one change per field, got %d", len(changes))
+	}
+	for _, ch := range changes {
+		if ch.Update == nil {
+			t.Fatalf("field %s not decoded", ch.Field)
+		}
+	}
+
+	ts, ok := changes[0].Update.(*TemplateStatusUpdate)
+	if !ok || ts.Event != "PAUSED" || ts.MessageTemplateID != 1689556908129832 || ts.OtherInfo == nil || ts.OtherInfo.Title != "FIRST_PAUSE" {
+		t.Fatalf("template status: %+v", changes[0].Update)
+	}
+	if q := changes[1].Update.(*TemplateQualityUpdate); q.NewQualityScore != "YELLOW" {
+		t.Fatalf("template quality: %+v", q)
+	}
+	if c := changes[2].Update.(*TemplateCategoryUpdate); c.NewCategory != "MARKETING" {
+		t.Fatalf("template category: %+v", c)
+	}
+	if q := changes[3].Update.(*PhoneNumberQualityUpdate); q.CurrentLimit != "TIER_10K" || q.OldLimit != "TIER_1K" {
+		t.Fatalf("phone quality: %+v", q)
+	}
+	if n := changes[4].Update.(*PhoneNumberNameUpdate); n.Decision != "REJECTED" || n.RejectionReason == "" {
+		t.Fatalf("phone name: %+v", n)
+	}
+	if a := changes[5].Update.(*AccountUpdate); a.ViolationInfo == nil || a.ViolationInfo.ViolationType != "SCAM" || len(a.RestrictionInfo) != 1 {
+		t.Fatalf("account: %+v", a)
+	}
+	if r := changes[6].Update.(*AccountReviewUpdate); r.Decision != "APPROVED" {
+		t.Fatalf("account review: %+v", r)
+	}
+	if c := changes[7].Update.(*BusinessCapabilityUpdate); c.MaxDailyConversationPerPhone != 100000 {
+		t.Fatalf("capability: %+v", c)
+	}
+	if s := changes[8].Update.(*SecurityUpdate); s.Event != "PIN_RESET_REQUEST" {
+		t.Fatalf("security: %+v", s)
+	}
+	up := changes[9].Update.(*UserPreferencesUpdate)
+	if len(up.UserPreferences) != 1 || up.UserPreferences[0].Value != "stop" || up.Metadata.PhoneNumberID != "106540352242922" {
+		t.Fatalf("user preferences: %+v", up)
+	}
+	if md := changes[9].Metadata(); md == nil || md.PhoneNumberID != "106540352242922" {
+		t.Fatalf("user preferences metadata: %+v", md)
+	}
+	if md := changes[0].Metadata(); md != nil {
+		t.Fatalf("template status has no phone metadata, got %+v", md)
+	}
+
+	// Re-encoding keeps the typed values.
+	out, err := json.Marshal(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := ParseWebhookEvent(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := again.Entry[0].Changes[8].Update.(*SecurityUpdate); *got != *changes[8].Update.(*SecurityUpdate) {
+		t.Fatalf("round trip: %+v", got)
+	}
+}
+
+func TestParseWebhookEvent_MessagesFieldUntyped(t *testing.T) {
+	e, err := ParseWebhookEvent([]byte(`{"entry":[{"changes":[{"field":"messages","value":{"messages":[{"id":"m1"}]}},{"field":"brand_new","value":{}}]}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ch := range e.Entry[0].Changes {
+		if ch.Update != nil {
+			t.Fatalf("field %s: unexpected typed value %T", ch.Field, ch.Update)
+		}
+	}
+	if e.Entry[0].Changes[0].Value.Messages[0].ID != "m1" {
+		t.Fatalf("messages not decoded: %+v", e.Entry[0].Changes[0])
+	}
+}
+
+func TestParseWebhookEvent_FieldTypeMismatch(t *testing.T) {
+	in := `{"entry":[{"changes":[` +
+		`{"field":"message_template_status_update","value":{"event":"PAUSED","message_template_id":"not-a-number"}},` +
+		`{"field":"messages","value":{"messages":[{"id":"m1"}]}}]}]}`
+	e, err := ParseWebhookEvent([]byte(in))
+	if err != nil {
+		t.Fatalf("a mismatched field failed the event: %v", err)
+	}
+	ch := e.Entry[0].Changes[0]
+	if ch.Update != nil || !strings.Contains(string(ch.Raw), "not-a-number") {
+		t.Fatalf("mismatched change: Update=%v Raw=%s", ch.Update, ch.Raw)
+	}
+	if e.Entry[0].Changes[1].Value.Messages[0].ID != "m1" {
+		t.Fatalf("messages not decoded: %+v", e.Entry[0].Changes[1])
+	}
+	b, err := json.Marshal(ch)
+	if err != nil || !strings.Contains(string(b), `"message_template_id":"not-a-number"`) {
+		t.Fatalf("raw value not re-encoded: %s, %v", b, err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/diegoyosiura/whatsapp-sdk-go/internal/httpx"
 	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/errorsx"
 	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/ports"
 )
@@ -18,6 +19,8 @@ type Options struct {
 	// Business account and phone number identifiers.
 	WABAID        string
 	PhoneNumberID string
+	// Meta app ID; only needed by the Resumable Upload API (Client.Uploads).
+	AppID string
 
 	// Transport and providers (required).
 	HTTPDoer        ports.HTTPDoer
@@ -28,11 +31,29 @@ type Options struct {
 	BaseURL string
 
 	// Network and resilience settings.
-	Timeout   time.Duration // per-request timeout
+	Timeout   time.Duration // per-request timeout; a sooner ctx deadline wins
 	RetryMax  int           // max retries for retryable statuses
 	UserAgent string        // appended to default UA if non-empty
+
+	// OnRetry is called before the default HTTPDoer waits to retry a request;
+	// useful for logging and metrics. Ignored when HTTPDoer is set.
+	OnRetry func(RetryInfo)
+
+	// Detached timeouts used by MediaService.GetMedia/DownloadMedia, which keep
+	// running after the caller's context (e.g. a webhook request) is canceled.
+	// Zero means 10s to resolve the media URL and 30s to download it. They
+	// replace Timeout for those requests.
+	MediaURLTimeout      time.Duration
+	MediaDownloadTimeout time.Duration
+	// MediaUploadTimeout bounds MediaService.UploadMedia/UploadMediaFrom in
+	// place of Timeout. Zero means 5 minutes.
+	MediaUploadTimeout time.Duration
 }
 
+// RetryInfo describes a retry of the default HTTPDoer: attempt number, delay
+// (from Retry-After / Meta usage headers or exponential backoff) and cause.
+type RetryInfo = httpx.RetryInfo
+
 // Validate checks that Options contain a minimal viable configuration.
 func (o *Options) Validate() error {
 	if o == nil {
@@ -65,6 +86,15 @@ func (o *Options) withDefaults() Options {
 	if cpy.RetryMax == 0 {
 		cpy.RetryMax = 3
 	}
+	if cpy.MediaURLTimeout == 0 {
+		cpy.MediaURLTimeout = 10 * time.Second
+	}
+	if cpy.MediaDownloadTimeout == 0 {
+		cpy.MediaDownloadTimeout = 30 * time.Second
+	}
+	if cpy.MediaUploadTimeout == 0 {
+		cpy.MediaUploadTimeout = 5 * time.Minute
+	}
 	return cpy
 }
 
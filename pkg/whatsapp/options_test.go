@@ -52,6 +52,9 @@ func TestOptionsWithDefaults(t *testing.T) {
 	if d.Timeout != 10*time.Second || d.RetryMax != 3 {
 		t.Fatalf("unexpected defaults: %+v", d)
 	}
+	if d.MediaURLTimeout != 10*time.Second || d.MediaDownloadTimeout != 30*time.Second || d.MediaUploadTimeout != 5*time.Minute {
+		t.Fatalf("unexpected media timeout defaults: %+v", d)
+	}
 	o.Timeout = time.Second
 	o.RetryMax = 5
 	d = o.withDefaults()
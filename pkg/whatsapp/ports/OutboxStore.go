@@ -0,0 +1,30 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
+)
+
+// OutboxStore persists outbound messages so that sends survive restarts.
+// Delivery is at-least-once: an item claimed by a worker that stops before
+// MarkSent becomes claimable again once its lease expires.
+//
+// Implementations must be safe for concurrent use; two concurrent Claim calls
+// never return the same item while its lease is valid.
+type OutboxStore interface {
+	// Enqueue stores item as pending. It reports false, without error, when an
+	// item with the same Key already exists.
+	Enqueue(ctx context.Context, item domain.OutboxItem) (bool, error)
+	// Claim leases up to n items, oldest first, that are pending and due or
+	// whose lease expired. Each claim increments Attempts.
+	Claim(ctx context.Context, n int, lease time.Duration) ([]domain.OutboxItem, error)
+	// MarkSent records that the item was accepted by the API as wamid.
+	MarkSent(ctx context.Context, key, wamid string) error
+	// MarkFailed records cause. A zero retryAt fails the item permanently;
+	// otherwise it becomes pending again from retryAt.
+	MarkFailed(ctx context.Context, key, cause string, retryAt time.Time) error
+	// Get returns the item stored under key.
+	Get(ctx context.Context, key string) (domain.OutboxItem, error)
+}
@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// SeenStore remembers webhook deliveries so services.WebhookDispatcher can
+// skip the ones Meta sends again. Implementations must be safe for
+// concurrent use.
+type SeenStore interface {
+	// MarkSeen records key for ttl and reports whether this is the first time
+	// it is seen within that window. Check and record must be atomic: of two
+	// concurrent calls with the same key only one may return true.
+	MarkSeen(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	// Forget removes key, so its next MarkSeen returns true. It is called
+	// when handling failed and the delivery must be accepted again.
+	Forget(ctx context.Context, key string) error
+}
@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
+)
+
+// StatusStore persists message delivery state for services.StatusTracker,
+// keyed by wamid. The tracker serializes updates to a message, so
+// implementations only need to be safe for concurrent use.
+type StatusStore interface {
+	// Get returns the message and true, or false when wamid is unknown.
+	Get(ctx context.Context, wamid string) (domain.TrackedMessage, bool, error)
+	Put(ctx context.Context, m domain.TrackedMessage) error
+	Delete(ctx context.Context, wamid string) error
+}
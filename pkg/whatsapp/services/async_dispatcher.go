@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
+)
+
+var (
+	// ErrQueueFull is returned by AsyncDispatcher.Enqueue in BackpressureReject mode.
+	ErrQueueFull = errors.New("webhook queue full")
+	// ErrDispatcherClosed is returned by AsyncDispatcher.Enqueue after Shutdown.
+	ErrDispatcherClosed = errors.New("webhook dispatcher shut down")
+)
+
+// Backpressure selects what AsyncDispatcher.Enqueue does when the queue is full.
+type Backpressure int
+
+const (
+	// BackpressureBlock waits for room in the queue (or for ctx to end).
+	BackpressureBlock Backpressure = iota
+	// BackpressureDrop discards the event; Meta will not redeliver it.
+	BackpressureDrop
+	// BackpressureReject returns ErrQueueFull so the webhook answers 503 and
+	// Meta redelivers the event later.
+	BackpressureReject
+)
+
+// Defaults of AsyncDispatcher.
+const (
+	DefaultWebhookQueueSize = 256
+	DefaultWebhookWorkers   = 4
+)
+
+// AsyncDispatcherOptions configures an AsyncDispatcher. Zero values select the defaults.
+type AsyncDispatcherOptions struct {
+	QueueSize    int // events waiting for a worker; default DefaultWebhookQueueSize
+	Workers      int // concurrent dispatches; default DefaultWebhookWorkers
+	Backpressure Backpressure
+	// OnPanic is called after a handler call panicked and was recovered.
+	// The default logs the value and stack.
+	OnPanic func(v any, stack []byte)
+}
+
+// AsyncDispatcher runs a WebhookDispatcher on a pool of workers fed by a
+// bounded queue, so the webhook endpoint can acknowledge events right away.
+// Each handler call (Always, OnMessage, OnStatus) recovers from panics on its
+// own, so one bad message does not stop the rest of the event. Handler errors
+// are logged: the event has already been acknowledged.
+type AsyncDispatcher struct {
+	d    *WebhookDispatcher
+	opts AsyncDispatcherOptions
+
+	queue chan asyncEvent
+	wg    sync.WaitGroup
+
+	// closing is closed by Shutdown to wake Enqueue calls blocked on a full
+	// queue; senders tracks the Enqueue calls past the closed check, which
+	// must return before the queue can be closed.
+	closing chan struct{}
+	senders sync.WaitGroup
+	mu      sync.Mutex // guards closed, drained and senders.Add
+	closed  bool
+	drained chan struct{} // closed once the workers are done
+	dropped atomic.Uint64
+}
+
+type asyncEvent struct {
+	e domain.WebhookEvent
+	h http.Header
+}
+
+// NewAsyncDispatcher starts the workers; stop them with Shutdown.
+func NewAsyncDispatcher(d *WebhookDispatcher, o AsyncDispatcherOptions) *AsyncDispatcher {
+	if o.QueueSize <= 0 {
+		o.QueueSize = DefaultWebhookQueueSize
+	}
+	if o.Workers <= 0 {
+		o.Workers = DefaultWebhookWorkers
+	}
+	if o.OnPanic == nil {
+		o.OnPanic = func(v any, stack []byte) { log.Printf("webhook handler panic: %v\n%s", v, stack) }
+	}
+	a := &AsyncDispatcher{d: d, opts: o, queue: make(chan asyncEvent, o.QueueSize), closing: make(chan struct{})}
+	a.wg.Add(o.Workers)
+	for i := 0; i < o.Workers; i++ {
+		go a.work()
+	}
+	return a
+}
+
+// Enqueue queues e for dispatch. When the queue is full it blocks, drops the
+// event (returning nil) or returns ErrQueueFull, per the Backpressure option.
+// A blocked Enqueue returns ErrDispatcherClosed once Shutdown is called.
+func (a *AsyncDispatcher) Enqueue(ctx context.Context, e domain.WebhookEvent, h http.Header) error {
+	a.mu.Lock()
+	if a.closed {
+		a.mu.Unlock()
+		return ErrDispatcherClosed
+	}
+	a.senders.Add(1)
+	a.mu.Unlock()
+	defer a.senders.Done()
+	ev := asyncEvent{e: e, h: h.Clone()}
+
+	select {
+	case a.queue <- ev:
+		return nil
+	default:
+	}
+	switch a.opts.Backpressure {
+	case BackpressureDrop:
+		a.dropped.Add(1)
+		log.Printf("webhook queue full: event dropped")
+		return nil
+	case BackpressureReject:
+		return ErrQueueFull
+	}
+	select {
+	case a.queue <- ev:
+		return nil
+	case <-a.closing:
+		return ErrDispatcherClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Dropped returns how many events were discarded in BackpressureDrop mode.
+func (a *AsyncDispatcher) Dropped() uint64 { return a.dropped.Load() }
+
+// Shutdown stops accepting events and waits until the queued and in-flight
+// events are dispatched, or until ctx is done. Enqueue calls blocked on a full
+// queue return ErrDispatcherClosed. Calling Shutdown again waits for the same
+// drain.
+func (a *AsyncDispatcher) Shutdown(ctx context.Context) error {
+	a.mu.Lock()
+	if !a.closed {
+		a.closed = true
+		a.drained = make(chan struct{})
+		close(a.closing)
+		go func() {
+			a.senders.Wait()
+			close(a.queue)
+			a.wg.Wait()
+			close(a.drained)
+		}()
+	}
+	drained := a.drained
+	a.mu.Unlock()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (a *AsyncDispatcher) work() {
+	defer a.wg.Done()
+	for ev := range a.queue {
+		// The event is already acknowledged, so errors can only be logged.
+		if err := a.d.dispatch(context.Background(), ev.e, ev.h, a.guard); err != nil {
+			log.Printf("webhook handler: %v", err)
+		}
+	}
+}
+
+// guard runs f, recovering and reporting a panic as an error.
+func (a *AsyncDispatcher) guard(f func() error) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			a.opts.OnPanic(v, debug.Stack())
+			err = fmt.Errorf("panic: %v", v)
+		}
+	}()
+	return f()
+}
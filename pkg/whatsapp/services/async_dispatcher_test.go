@@ -0,0 +1,173 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/services"
+)
+
+// gatedHandler records message IDs; OnMessage reports on started and waits
+// on gate when they are set, and panics for the ID "boom".
+type gatedHandler struct {
+	started chan struct{}
+	gate    chan struct{}
+
+	mu  sync.Mutex
+	ids []string
+}
+
+func (g *gatedHandler) Always(domain.WebhookEvent, http.Header) {}
+func (g *gatedHandler) OnMessage(m domain.InboundMessage, _ domain.WebhookEvent, _ http.Header) {
+	if g.started != nil {
+		g.started <- struct{}{}
+	}
+	if g.gate != nil {
+		<-g.gate
+	}
+	if m.ID == "boom" {
+		panic("handler failure")
+	}
+	g.mu.Lock()
+	g.ids = append(g.ids, m.ID)
+	g.mu.Unlock()
+}
+func (g *gatedHandler) OnStatus(domain.MessageStatus, domain.WebhookEvent, http.Header) {}
+
+func (g *gatedHandler) seen() []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]string(nil), g.ids...)
+}
+
+func messageEvent(ids ...string) domain.WebhookEvent {
+	msgs := make([]domain.InboundMessage, len(ids))
+	for i, id := range ids {
+		msgs[i] = domain.InboundMessage{ID: id}
+	}
+	return domain.WebhookEvent{Entry: []domain.WebhookEntry{{
+		Changes: []domain.WebhookChange{{Value: domain.WebhookValue{Messages: msgs}}},
+	}}}
+}
+
+func TestAsyncDispatcher_ShutdownDrainsQueue(t *testing.T) {
+	h := &gatedHandler{}
+	a := services.NewAsyncDispatcher(services.NewWebhookDispatcher(h), services.AsyncDispatcherOptions{Workers: 1, QueueSize: 10})
+
+	for _, id := range []string{"m1", "m2", "m3"} {
+		if err := a.Enqueue(context.Background(), messageEvent(id), http.Header{}); err != nil {
+			t.Fatalf("enqueue %s: %v", id, err)
+		}
+	}
+	if err := a.Shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	if got := h.seen(); len(got) != 3 || got[0] != "m1" || got[2] != "m3" {
+		t.Fatalf("dispatched %v, want m1..m3 in order", got)
+	}
+	if err := a.Enqueue(context.Background(), messageEvent("late"), http.Header{}); !errors.Is(err, services.ErrDispatcherClosed) {
+		t.Fatalf("enqueue after shutdown: %v", err)
+	}
+}
+
+func TestAsyncDispatcher_Backpressure(t *testing.T) {
+	// One worker stuck on the first event and a queue of one: the third event finds it full.
+	fill := func(t *testing.T, mode services.Backpressure) *services.AsyncDispatcher {
+		h := &gatedHandler{started: make(chan struct{}, 3), gate: make(chan struct{})}
+		a := services.NewAsyncDispatcher(services.NewWebhookDispatcher(h), services.AsyncDispatcherOptions{Workers: 1, QueueSize: 1, Backpressure: mode})
+		t.Cleanup(func() {
+			close(h.gate)
+			_ = a.Shutdown(context.Background())
+		})
+		if err := a.Enqueue(context.Background(), messageEvent("m1"), nil); err != nil {
+			t.Fatalf("enqueue m1: %v", err)
+		}
+		<-h.started // the worker holds m1
+		if err := a.Enqueue(context.Background(), messageEvent("m2"), nil); err != nil {
+			t.Fatalf("enqueue m2: %v", err)
+		}
+		return a
+	}
+
+	t.Run("reject", func(t *testing.T) {
+		a := fill(t, services.BackpressureReject)
+		if err := a.Enqueue(context.Background(), messageEvent("m3"), nil); !errors.Is(err, services.ErrQueueFull) {
+			t.Fatalf("got %v, want ErrQueueFull", err)
+		}
+	})
+	t.Run("drop", func(t *testing.T) {
+		a := fill(t, services.BackpressureDrop)
+		if err := a.Enqueue(context.Background(), messageEvent("m3"), nil); err != nil {
+			t.Fatalf("drop mode returned %v", err)
+		}
+		if a.Dropped() != 1 {
+			t.Fatalf("dropped = %d, want 1", a.Dropped())
+		}
+	})
+	t.Run("block", func(t *testing.T) {
+		a := fill(t, services.BackpressureBlock)
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		if err := a.Enqueue(ctx, messageEvent("m3"), nil); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("got %v, want deadline exceeded", err)
+		}
+	})
+	t.Run("block until shutdown", func(t *testing.T) {
+		a := fill(t, services.BackpressureBlock)
+		blocked := make(chan error)
+		go func() { blocked <- a.Enqueue(context.Background(), messageEvent("m3"), nil) }()
+		time.Sleep(10 * time.Millisecond) // let Enqueue block on the full queue
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		if err := a.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("Shutdown = %v, want deadline exceeded (m1 is still running)", err)
+		}
+		select {
+		case err := <-blocked:
+			if !errors.Is(err, services.ErrDispatcherClosed) {
+				t.Fatalf("blocked Enqueue = %v, want ErrDispatcherClosed", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Enqueue still blocked after Shutdown")
+		}
+	})
+}
+
+func TestAsyncDispatcher_RecoversPanics(t *testing.T) {
+	h := &gatedHandler{}
+	var panics []any
+	a := services.NewAsyncDispatcher(services.NewWebhookDispatcher(h), services.AsyncDispatcherOptions{
+		Workers: 1,
+		OnPanic: func(v any, _ []byte) { panics = append(panics, v) },
+	})
+	_ = a.Enqueue(context.Background(), messageEvent("m1", "boom", "m2"), nil)
+	_ = a.Enqueue(context.Background(), messageEvent("m3"), nil)
+	if err := a.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(panics) != 1 || panics[0] != "handler failure" {
+		t.Fatalf("panics = %v", panics)
+	}
+	if got := h.seen(); len(got) != 3 {
+		t.Fatalf("dispatched %v, want m1 m2 m3", got)
+	}
+}
+
+func TestAsyncDispatcher_ShutdownTimeout(t *testing.T) {
+	h := &gatedHandler{gate: make(chan struct{})}
+	defer close(h.gate)
+	a := services.NewAsyncDispatcher(services.NewWebhookDispatcher(h), services.AsyncDispatcherOptions{Workers: 1})
+	_ = a.Enqueue(context.Background(), messageEvent("m1"), nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := a.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want deadline exceeded", err)
+	}
+}
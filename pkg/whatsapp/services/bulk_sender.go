@@ -0,0 +1,240 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"iter"
+	"sync"
+	"time"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/internal/httpx"
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/errorsx"
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/utils"
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
+)
+
+// Defaults of BulkSender.
+const (
+	DefaultBulkWorkers      = 8
+	DefaultBulkMaxAttempts  = 3
+	DefaultBulkRetryBackoff = time.Second
+	maxBulkRetryBackoff     = 30 * time.Second
+)
+
+// MessageSender sends a single message; *MessagesService satisfies it.
+type MessageSender interface {
+	Send(ctx context.Context, payload *domain.SendMessage) (*domain.MessageSendResponse, error)
+}
+
+// BulkResult is the outcome of one input message.
+type BulkResult struct {
+	Index    int                 // position of the message in the input
+	Message  *domain.SendMessage // the input message
+	WAMID    string              // ID of the sent message when Err is nil
+	Response *domain.MessageSendResponse
+	Attempts int   // number of Send calls made
+	Err      error // last Send error, or the context error on cancellation
+}
+
+// BulkProgress is reported after every result.
+type BulkProgress struct {
+	Done      int // results emitted so far
+	Succeeded int
+	Failed    int
+}
+
+// BulkSender sends a stream of messages with bounded concurrency. Messages to
+// the same recipient are always handled by the same worker, so they are sent
+// in input order. Failures classified as errorsx.IsRetryable are retried with
+// exponential backoff (or the server's Retry-After hint).
+type BulkSender struct {
+	s           MessageSender
+	workers     int
+	maxAttempts int
+	backoff     time.Duration
+	onProgress  func(BulkProgress)
+}
+
+func NewBulkSender(s MessageSender) *BulkSender {
+	return &BulkSender{s: s, workers: DefaultBulkWorkers, maxAttempts: DefaultBulkMaxAttempts, backoff: DefaultBulkRetryBackoff}
+}
+
+// WithWorkers sets how many messages are sent concurrently. n <= 0 restores the default.
+func (b *BulkSender) WithWorkers(n int) *BulkSender {
+	if n <= 0 {
+		n = DefaultBulkWorkers
+	}
+	b.workers = n
+	return b
+}
+
+// WithMaxAttempts sets how many times a message is tried, including the first
+// attempt. n <= 0 restores the default; 1 disables retries.
+func (b *BulkSender) WithMaxAttempts(n int) *BulkSender {
+	if n <= 0 {
+		n = DefaultBulkMaxAttempts
+	}
+	b.maxAttempts = n
+	return b
+}
+
+// WithRetryBackoff sets the delay before the first retry; it doubles on each
+// further retry. d <= 0 restores the default.
+func (b *BulkSender) WithRetryBackoff(d time.Duration) *BulkSender {
+	if d <= 0 {
+		d = DefaultBulkRetryBackoff
+	}
+	b.backoff = d
+	return b
+}
+
+// WithProgress registers fn to be called after each result. Calls are
+// serialized and happen before the result is delivered on the channel.
+func (b *BulkSender) WithProgress(fn func(BulkProgress)) *BulkSender {
+	b.onProgress = fn
+	return b
+}
+
+// Run sends every message received from in until in is closed or ctx is done,
+// and returns a channel with one BulkResult per message read from in. The
+// channel is closed once all of them were delivered, so it must be drained.
+// After cancellation, messages already read but not yet sent are reported with
+// the context error; messages still in in are left unread.
+func (b *BulkSender) Run(ctx context.Context, in <-chan *domain.SendMessage) <-chan BulkResult {
+	return b.SendAll(ctx, func(yield func(*domain.SendMessage) bool) {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case m, ok := <-in:
+				if !ok || !yield(m) {
+					return
+				}
+			}
+		}
+	})
+}
+
+// SendAll is like Run but reads the messages from an iterator.
+func (b *BulkSender) SendAll(ctx context.Context, msgs iter.Seq[*domain.SendMessage]) <-chan BulkResult {
+	out := make(chan BulkResult, b.workers)
+	type job struct {
+		index int
+		msg   *domain.SendMessage
+	}
+	queues := make([]chan job, b.workers)
+	for i := range queues {
+		queues[i] = make(chan job, 1)
+	}
+
+	var (
+		mu       sync.Mutex
+		progress BulkProgress
+	)
+	emit := func(r BulkResult) {
+		mu.Lock()
+		progress.Done++
+		if r.Err == nil {
+			progress.Succeeded++
+		} else {
+			progress.Failed++
+		}
+		if b.onProgress != nil {
+			b.onProgress(progress)
+		}
+		mu.Unlock()
+		out <- r
+	}
+
+	var wg sync.WaitGroup
+	for _, q := range queues {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range q {
+				emit(b.send(ctx, j.index, j.msg))
+			}
+		}()
+	}
+
+	go func() {
+		defer func() {
+			for _, q := range queues {
+				close(q)
+			}
+			wg.Wait()
+			close(out)
+		}()
+		i := 0
+		for m := range msgs {
+			j := job{index: i, msg: m}
+			i++
+			select {
+			case queues[shard(m, b.workers)] <- j:
+			case <-ctx.Done():
+				emit(BulkResult{Index: j.index, Message: m, Err: ctx.Err()})
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// send delivers one message, retrying transient failures.
+func (b *BulkSender) send(ctx context.Context, index int, m *domain.SendMessage) BulkResult {
+	r := BulkResult{Index: index, Message: m}
+	if m == nil {
+		r.Err = &errorsx.ValidationError{Op: "BulkSend", Field: "payload", Reason: "nil"}
+		return r
+	}
+	wait := b.backoff
+	for {
+		if err := ctx.Err(); err != nil {
+			if r.Err == nil {
+				r.Err = err
+			}
+			return r
+		}
+		r.Attempts++
+		resp, err := b.s.Send(ctx, m)
+		if err == nil {
+			r.Response, r.Err = resp, nil
+			if len(resp.Messages) > 0 {
+				r.WAMID = resp.Messages[0].ID
+			}
+			return r
+		}
+		r.Err = err
+		if r.Attempts >= b.maxAttempts || !errorsx.IsRetryable(err) {
+			return r
+		}
+		if err := sleepCtx(ctx, retryWait(err, wait)); err != nil {
+			return r
+		}
+		wait = min(2*wait, maxBulkRetryBackoff)
+	}
+}
+
+// retryWait prefers the delay requested by the server over the backoff.
+func retryWait(err error, backoff time.Duration) time.Duration {
+	var he *errorsx.HTTPError
+	if errors.As(err, &he) && he.Headers != nil {
+		if d := httpx.RetryAfter(he.Headers, time.Now()); d > 0 {
+			return min(d, maxBulkRetryBackoff)
+		}
+	}
+	return backoff
+}
+
+// shard maps a recipient to a worker so its messages stay in order. The
+// number is hashed as Validate normalizes it, so "+55 11 9..." and "55119..."
+// share a worker.
+func shard(m *domain.SendMessage, n int) int {
+	if m == nil {
+		return 0
+	}
+	h := fnv.New32a()
+	h.Write([]byte(utils.OnlyDigits(m.To)))
+	return int(h.Sum32() % uint32(n))
+}
@@ -0,0 +1,205 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/errorsx"
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/services"
+)
+
+// fakeSender records the text bodies sent per recipient and fails according to fail.
+type fakeSender struct {
+	mu    sync.Mutex
+	sent  map[string][]string
+	calls map[string]int
+	fail  func(to string, call int) error
+	delay time.Duration
+}
+
+func (f *fakeSender) Send(ctx context.Context, m *domain.SendMessage) (*domain.MessageSendResponse, error) {
+	time.Sleep(f.delay)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.sent == nil {
+		f.sent, f.calls = map[string][]string{}, map[string]int{}
+	}
+	f.calls[m.To]++
+	if f.fail != nil {
+		if err := f.fail(m.To, f.calls[m.To]); err != nil {
+			return nil, err
+		}
+	}
+	f.sent[m.To] = append(f.sent[m.To], m.TextMessage.Text.Body)
+	out := &domain.MessageSendResponse{}
+	out.Messages = append(out.Messages, struct {
+		ID string `json:"id"`
+	}{ID: fmt.Sprintf("wamid.%s.%d", m.To, len(f.sent[m.To]))})
+	return out, nil
+}
+
+func graphCodeError(code int) error {
+	req, _ := http.NewRequest(http.MethodPost, "http://graph.local/messages", nil)
+	resp := &http.Response{StatusCode: 400, Status: "400 Bad Request", Header: http.Header{}, Request: req}
+	return errorsx.TryParseGraphError(resp, []byte(fmt.Sprintf(`{"error":{"message":"m","code":%d}}`, code)))
+}
+
+func collect(ch <-chan services.BulkResult) []services.BulkResult {
+	var rs []services.BulkResult
+	for r := range ch {
+		rs = append(rs, r)
+	}
+	slices.SortFunc(rs, func(a, b services.BulkResult) int { return a.Index - b.Index })
+	return rs
+}
+
+func TestBulkSender_OrderingRetriesAndResults(t *testing.T) {
+	f := &fakeSender{
+		delay: time.Millisecond,
+		fail: func(to string, call int) error {
+			switch {
+			case to == "retry" && call == 1:
+				return graphCodeError(130429) // transient
+			case to == "bad":
+				return graphCodeError(131026) // permanent
+			}
+			return nil
+		},
+	}
+
+	var msgs []*domain.SendMessage
+	for i := 0; i < 10; i++ {
+		for _, to := range []string{"a", "b", "c"} {
+			msgs = append(msgs, domain.NewSendTextMessage(to, fmt.Sprint(i)))
+		}
+	}
+	msgs = append(msgs, domain.NewSendTextMessage("retry", "r"), domain.NewSendTextMessage("bad", "x"))
+
+	var last services.BulkProgress
+	b := services.NewBulkSender(f).WithWorkers(4).WithRetryBackoff(time.Millisecond).
+		WithProgress(func(p services.BulkProgress) { last = p })
+	rs := collect(b.SendAll(context.Background(), slices.Values(msgs)))
+
+	if len(rs) != len(msgs) {
+		t.Fatalf("expected %d results, got %d", len(msgs), len(rs))
+	}
+	for _, to := range []string{"a", "b", "c"} {
+		want := []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"}
+		if !slices.Equal(f.sent[to], want) {
+			t.Fatalf("order for %s: got %v", to, f.sent[to])
+		}
+	}
+	retry, bad := rs[len(rs)-2], rs[len(rs)-1]
+	if retry.Err != nil || retry.Attempts != 2 || retry.WAMID != "wamid.retry.1" {
+		t.Fatalf("retryable failure not retried: %+v", retry)
+	}
+	if !errors.Is(bad.Err, errorsx.ErrUndeliverable) || bad.Attempts != 1 {
+		t.Fatalf("permanent failure retried or lost: %+v", bad)
+	}
+	if rs[0].Index != 0 || rs[0].WAMID != "wamid.a.1" || rs[0].Message != msgs[0] {
+		t.Fatalf("unexpected first result: %+v", rs[0])
+	}
+	if last.Done != len(msgs) || last.Succeeded != len(msgs)-1 || last.Failed != 1 {
+		t.Fatalf("unexpected final progress: %+v", last)
+	}
+}
+
+// orderSender records the order of text bodies sent, holding back the ones in slow.
+type orderSender struct {
+	mu   sync.Mutex
+	sent []string
+	slow map[string]bool
+}
+
+func (o *orderSender) Send(ctx context.Context, m *domain.SendMessage) (*domain.MessageSendResponse, error) {
+	if o.slow[m.TextMessage.Text.Body] {
+		time.Sleep(20 * time.Millisecond)
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.sent = append(o.sent, m.TextMessage.Text.Body)
+	return &domain.MessageSendResponse{}, nil
+}
+
+func TestBulkSender_ShardsByNormalizedRecipient(t *testing.T) {
+	o := &orderSender{slow: map[string]bool{}}
+	var msgs []*domain.SendMessage
+	for i := 0; i < 4; i++ {
+		first, second := fmt.Sprint(i, "a"), fmt.Sprint(i, "b")
+		o.slow[first] = true
+		msgs = append(msgs,
+			domain.NewSendTextMessage(fmt.Sprintf("+55 11 99999-000%d", i), first),
+			domain.NewSendTextMessage(fmt.Sprintf("551199999000%d", i), second))
+	}
+	rs := collect(services.NewBulkSender(o).WithWorkers(8).SendAll(context.Background(), slices.Values(msgs)))
+	if len(rs) != len(msgs) {
+		t.Fatalf("expected %d results, got %d", len(msgs), len(rs))
+	}
+	for i := 0; i < 4; i++ {
+		a, b := slices.Index(o.sent, fmt.Sprint(i, "a")), slices.Index(o.sent, fmt.Sprint(i, "b"))
+		if a < 0 || b < 0 || a > b {
+			t.Fatalf("messages to the same number sent out of order: %v", o.sent)
+		}
+	}
+}
+
+func TestBulkSender_RunCancels(t *testing.T) {
+	f := &fakeSender{delay: 5 * time.Millisecond}
+	in := make(chan *domain.SendMessage)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	b := services.NewBulkSender(f).WithWorkers(2)
+	out := b.Run(ctx, in)
+	go func() {
+		for i := 0; ; i++ {
+			select {
+			case in <- domain.NewSendTextMessage(fmt.Sprint(i%5), "x"):
+			case <-ctx.Done():
+				return
+			}
+			if i == 20 {
+				cancel()
+			}
+		}
+	}()
+
+	done := make(chan []services.BulkResult)
+	go func() { done <- collect(out) }()
+	select {
+	case rs := <-done:
+		if len(rs) == 0 || len(rs) > 30 {
+			t.Fatalf("unexpected result count after cancel: %d", len(rs))
+		}
+		for _, r := range rs {
+			if r.Err != nil && !errors.Is(r.Err, context.Canceled) {
+				t.Fatalf("unexpected error: %v", r.Err)
+			}
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("result stream not closed after cancel")
+	}
+}
+
+func TestBulkSender_RunDrainsClosedChannel(t *testing.T) {
+	in := make(chan *domain.SendMessage, 3)
+	in <- domain.NewSendTextMessage("a", "1")
+	in <- nil
+	in <- domain.NewSendTextMessage("a", "2")
+	close(in)
+
+	rs := collect(services.NewBulkSender(&fakeSender{}).Run(context.Background(), in))
+	if len(rs) != 3 || rs[0].Err != nil || rs[2].Err != nil {
+		t.Fatalf("unexpected results: %+v", rs)
+	}
+	var ve *errorsx.ValidationError
+	if !errors.As(rs[1].Err, &ve) {
+		t.Fatalf("nil message should fail validation, got %v", rs[1].Err)
+	}
+}
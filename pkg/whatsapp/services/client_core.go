@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
 	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/ports"
 )
 
@@ -12,12 +13,22 @@ import (
 // Client's configured HTTPDoer and TokenProvider to execute them.
 type MessagesService struct {
 	c clientCore
+
+	tv  TemplateSendValidator
+	lim SendLimiter
+}
+
+// TemplateSendValidator performs extra checks on a message before it is sent;
+// *TemplateValidator satisfies it.
+type TemplateSendValidator interface {
+	ValidateTemplate(ctx context.Context, msg *domain.SendMessage) error
 }
 
 // clientCore is the minimal facade the service needs; *whatsapp.Client satisfies it.
 type clientCore interface {
 	BaseURL() string
 	Version() string
+	WABAID() string
 	PhoneNumberID() string
 	TokenProvider() ports.TokenProvider
 	Do(ctx context.Context, req *http.Request) (*http.Response, error)
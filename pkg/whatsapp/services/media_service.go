@@ -1,44 +1,132 @@
 package services
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
-	"net/http"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/diegoyosiura/whatsapp-sdk-go/internal/httpx"
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/errorsx"
 	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
 	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/ports"
 	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/transport/graph"
 )
 
+// DefaultMaxDownloadSize caps media downloads; 100 MB is the largest media
+// (documents) WhatsApp accepts.
+const DefaultMaxDownloadSize int64 = 100 << 20
+
+// Default detached timeouts of GetMedia and DownloadMedia.
+const (
+	DefaultMediaURLTimeout      = 10 * time.Second
+	DefaultMediaDownloadTimeout = 30 * time.Second
+)
+
+// DefaultMediaUploadTimeout bounds UploadMedia/UploadMediaFrom; it leaves room
+// for a 100 MB video on a modest uplink.
+const DefaultMediaUploadTimeout = 5 * time.Minute
+
 type MediaService struct {
 	api ports.MediaAPI
 	c   clientCore
+
+	maxDownload     int64
+	urlTimeout      time.Duration
+	downloadTimeout time.Duration
+	uploadTimeout   time.Duration
+}
+
+// InboundMedia is the downloaded content of an inbound media message along with
+// the metadata the webhook carried (ID, MIME type, caption, filename...).
+type InboundMedia struct {
+	Type string // image, audio, video, document or sticker
+	domain.MediaObject
+	FileSize int
+	File     ports.FileManagerAPI
 }
 
 func NewMediaService(c clientCore) *MediaService {
-	return &MediaService{api: graph.NewMediaAPI(), c: c}
+	return &MediaService{
+		api:             graph.NewMediaAPI(),
+		c:               c,
+		maxDownload:     DefaultMaxDownloadSize,
+		urlTimeout:      DefaultMediaURLTimeout,
+		downloadTimeout: DefaultMediaDownloadTimeout,
+		uploadTimeout:   DefaultMediaUploadTimeout,
+	}
 }
 
+// WithTimeouts sets the timeouts used to resolve the media URL and to download
+// it; they replace the client's per-request timeout for GetMediaURL and
+// DownloadTo, and bound the detached contexts of GetMedia/DownloadMedia. Zero
+// keeps the current value.
+func (s *MediaService) WithTimeouts(urlTimeout, downloadTimeout time.Duration) *MediaService {
+	if urlTimeout > 0 {
+		s.urlTimeout = urlTimeout
+	}
+	if downloadTimeout > 0 {
+		s.downloadTimeout = downloadTimeout
+	}
+	return s
+}
+
+// WithUploadTimeout sets the timeout of UploadMedia/UploadMediaFrom, which
+// replaces the client's per-request timeout for uploads. Zero keeps the
+// current value.
+func (s *MediaService) WithUploadTimeout(d time.Duration) *MediaService {
+	if d > 0 {
+		s.uploadTimeout = d
+	}
+	return s
+}
+
+// WithMaxDownloadSize changes the largest body DownloadTo and DownloadMedia
+// accept. n <= 0 restores DefaultMaxDownloadSize.
+func (s *MediaService) WithMaxDownloadSize(n int64) *MediaService {
+	if n <= 0 {
+		n = DefaultMaxDownloadSize
+	}
+	s.maxDownload = n
+	return s
+}
+
+// UploadMedia uploads f and closes it once the upload has finished. The MIME
+// type is detected from the file name and content.
 func (s *MediaService) UploadMedia(ctx context.Context, f *os.File) (*domain.MediaUpload, error) {
-	rq, err := s.api.Upload(ctx, s.c.BaseURL(), s.c.Version(), s.c.PhoneNumberID(), f, s.c.TokenProvider())
+	defer func() {
+		_ = f.Close()
+	}()
+	return s.UploadMediaFrom(ctx, f, f.Name(), "")
+}
+
+// UploadMediaFrom streams r to the media endpoint without buffering it in
+// memory. mimeType should be set explicitly (e.g. "video/mp4"); when empty it is
+// detected from filename and the first bytes of r. r is not closed, and it is
+// only retried on transient failures when it implements io.Seeker.
+func (s *MediaService) UploadMediaFrom(ctx context.Context, r io.Reader, filename, mimeType string) (*domain.MediaUpload, error) {
+	rq, err := s.api.Upload(ctx, s.c.BaseURL(), s.c.Version(), s.c.PhoneNumberID(), r, filename, mimeType, s.c.TokenProvider())
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := s.c.Do(ctx, rq)
-
+	resp, err := s.c.Do(httpx.WithTimeout(ctx, s.uploadTimeout), rq)
+	if err != nil {
+		return nil, err
+	}
 	defer func() {
 		_ = resp.Body.Close()
 	}()
 
-	if resp.StatusCode != http.StatusOK {
-		b, _ := io.ReadAll(io.LimitReader(resp.Body, 8<<10))
-		return nil, fmt.Errorf("bad status: %s - %s", resp.Status, string(b))
+	if err := graph.CheckResponse(resp); err != nil {
+		return nil, err
 	}
 
 	b, err := io.ReadAll(resp.Body)
@@ -66,14 +154,15 @@ func (s *MediaService) DeleteMedia(ctx context.Context, mediaID string) (*domain
 	}
 
 	resp, err := s.c.Do(ctx, rq)
-
+	if err != nil {
+		return nil, err
+	}
 	defer func() {
 		_ = resp.Body.Close()
 	}()
 
-	if resp.StatusCode != http.StatusOK {
-		b, _ := io.ReadAll(io.LimitReader(resp.Body, 8<<10))
-		return nil, fmt.Errorf("bad status: %s - %s", resp.Status, string(b))
+	if err := graph.CheckResponse(resp); err != nil {
+		return nil, err
 	}
 
 	b, err := io.ReadAll(resp.Body)
@@ -99,7 +188,7 @@ func (s *MediaService) GetMediaURL(ctx context.Context, mediaID string) (*domain
 		return nil, err
 	}
 
-	resp, err := s.c.Do(ctx, rq) // use gctx here
+	resp, err := s.c.Do(httpx.WithTimeout(ctx, s.urlTimeout), rq)
 	if err != nil {
 		return nil, err
 	}
@@ -108,9 +197,8 @@ func (s *MediaService) GetMediaURL(ctx context.Context, mediaID string) (*domain
 		_ = resp.Body.Close()
 	}()
 
-	if resp.StatusCode != http.StatusOK {
-		b, _ := io.ReadAll(io.LimitReader(resp.Body, 8<<10))
-		return nil, fmt.Errorf("get media url bad status: %s - %s", resp.Status, string(b))
+	if err := graph.CheckResponse(resp); err != nil {
+		return nil, err
 	}
 
 	d := &domain.DownloadLinkURL{}
@@ -121,67 +209,121 @@ func (s *MediaService) GetMediaURL(ctx context.Context, mediaID string) (*domain
 	return d, nil
 }
 
-func (s *MediaService) DownloadMedia(ctx context.Context, d *domain.DownloadLinkURL, fm ports.FileManagerAPI) (ports.FileManagerAPI, error) {
-	base := context.WithoutCancel(ctx)
-	dctx, cancel := context.WithTimeout(base, 30*time.Second)
-	defer cancel()
+// DownloadTo streams the media behind d into w without buffering it. The
+// transfer fails with *errorsx.IntegrityError when it exceeds the configured
+// max size, or when the size or SHA-256 advertised in d do not match what was
+// received. In that case w may already hold partial data and must be discarded.
+func (s *MediaService) DownloadTo(ctx context.Context, d *domain.DownloadLinkURL, w io.Writer) (int64, error) {
+	const op = "DownloadTo"
+	if d == nil || d.Url == "" {
+		return 0, &errorsx.ValidationError{Op: op, Field: "url", Reason: "empty"}
+	}
+	if int64(d.FileSize) > s.maxDownload {
+		return 0, &errorsx.IntegrityError{Op: op, Field: "max_size", Expected: fmt.Sprintf("<= %d bytes", s.maxDownload), Actual: fmt.Sprintf("%d bytes advertised", d.FileSize)}
+	}
 
-	rq, err := s.api.Download(dctx, d, s.c.TokenProvider())
+	rq, err := s.api.Download(ctx, d, s.c.TokenProvider())
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 
-	resp, err := s.c.Do(dctx, rq) // use dctx aqui
+	resp, err := s.c.Do(httpx.WithTimeout(ctx, s.downloadTimeout), rq)
 	if err != nil {
 		if errors.Is(err, context.Canceled) {
-			return nil, fmt.Errorf("download canceled: %w", err)
+			return 0, fmt.Errorf("download canceled: %w", err)
 		}
 		if errors.Is(err, context.DeadlineExceeded) {
-			return nil, fmt.Errorf("download timeout: %w", err)
+			return 0, fmt.Errorf("download timeout: %w", err)
 		}
-		return nil, err
+		return 0, err
 	}
 	defer func() {
-		io.Copy(io.Discard, resp.Body)
-		resp.Body.Close()
+		_ = resp.Body.Close()
 	}()
 
-	if resp.StatusCode != http.StatusOK {
-		b, _ := io.ReadAll(io.LimitReader(resp.Body, 8<<10))
-		return nil, fmt.Errorf("bad status: %s - %s", resp.Status, string(b))
+	if err := graph.CheckResponse(resp); err != nil {
+		return 0, err
 	}
 
-	b, err := io.ReadAll(resp.Body)
+	// Read one byte past the limit to tell "exactly max" from "too large".
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(w, h), io.LimitReader(resp.Body, s.maxDownload+1))
 	if err != nil {
 		if errors.Is(err, context.Canceled) {
-			return nil, fmt.Errorf("read canceled: %w", err)
+			return n, fmt.Errorf("read canceled: %w", err)
 		}
 		if errors.Is(err, context.DeadlineExceeded) {
-			return nil, fmt.Errorf("read timeout: %w", err)
+			return n, fmt.Errorf("read timeout: %w", err)
 		}
-		return nil, fmt.Errorf("read response body: %w", err)
+		return n, fmt.Errorf("read response body: %w", err)
+	}
+
+	if n > s.maxDownload {
+		return n, &errorsx.IntegrityError{Op: op, Field: "max_size", Expected: fmt.Sprintf("<= %d bytes", s.maxDownload), Actual: fmt.Sprintf("more than %d bytes", s.maxDownload)}
+	}
+	if d.FileSize > 0 && n != int64(d.FileSize) {
+		return n, &errorsx.IntegrityError{Op: op, Field: "file_size", Expected: fmt.Sprintf("%d bytes", d.FileSize), Actual: fmt.Sprintf("%d bytes", n)}
+	}
+	if d.Sha256 != "" {
+		if sum := hex.EncodeToString(h.Sum(nil)); !strings.EqualFold(sum, d.Sha256) {
+			return n, &errorsx.IntegrityError{Op: op, Field: "sha256", Expected: d.Sha256, Actual: sum}
+		}
+	}
+	return n, nil
+}
+
+// DownloadMedia downloads the media behind d (verified like DownloadTo) and
+// hands the bytes to fm. It runs on a context detached from ctx's
+// cancellation, bounded by the download timeout (30s by default).
+func (s *MediaService) DownloadMedia(ctx context.Context, d *domain.DownloadLinkURL, fm ports.FileManagerAPI) (ports.FileManagerAPI, error) {
+	base := context.WithoutCancel(ctx)
+	dctx, cancel := context.WithTimeout(base, s.downloadTimeout)
+	defer cancel()
+
+	buf := &bytes.Buffer{}
+	if d != nil && d.FileSize > 0 && int64(d.FileSize) <= s.maxDownload {
+		buf.Grow(d.FileSize)
+	}
+	if _, err := s.DownloadTo(dctx, d, buf); err != nil {
+		return nil, err
 	}
 
-	if err := fm.SetData(dctx, b); err != nil { // use dctx aqui também
+	if err := fm.SetData(dctx, buf.Bytes()); err != nil { // use dctx aqui também
 		return nil, fmt.Errorf("set data: %w", err)
 	}
 	return fm, nil
 }
-func (s *MediaService) GetMedia(ctx context.Context, message domain.InboundMessage, fm ports.FileManagerAPI) (ports.FileManagerAPI, error) {
-	switch message.Type {
-	case "audio":
-		// Detached context with a short timeout for metadata call
-		gbase := context.WithoutCancel(ctx)
-		gctx, gcancel := context.WithTimeout(gbase, 10*time.Second)
-		defer gcancel()
-
-		d, err := s.GetMediaURL(gctx, message.Audio.ID)
-		if err != nil {
-			return nil, err
-		}
 
-		return s.DownloadMedia(ctx, d, fm) // DownloadMedia criará seu próprio dctx
-	default:
-		return nil, errors.New("not supported")
+// GetMedia resolves and downloads the media of an inbound image, audio, video,
+// document or sticker message into fm. Both steps run detached from ctx's
+// cancellation so a finished webhook request does not abort them.
+func (s *MediaService) GetMedia(ctx context.Context, message domain.InboundMessage, fm ports.FileManagerAPI) (*InboundMedia, error) {
+	media := message.Media()
+	if media == nil || media.ID == "" {
+		return nil, &errorsx.ValidationError{Op: "GetMedia", Field: "type", Reason: fmt.Sprintf("message type %q carries no media", message.Type)}
+	}
+
+	// Detached context with a short timeout for metadata call
+	gbase := context.WithoutCancel(ctx)
+	gctx, gcancel := context.WithTimeout(gbase, s.urlTimeout)
+	defer gcancel()
+
+	d, err := s.GetMediaURL(gctx, media.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := s.DownloadMedia(ctx, d, fm) // DownloadMedia criará seu próprio dctx
+	if err != nil {
+		return nil, err
+	}
+
+	out := &InboundMedia{Type: message.Type, MediaObject: *media, FileSize: d.FileSize, File: file}
+	if out.MIMEType == "" {
+		out.MIMEType = d.MimeType
+	}
+	if out.SHA256 == "" {
+		out.SHA256 = d.Sha256
 	}
+	return out, nil
 }
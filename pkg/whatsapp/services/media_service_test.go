@@ -0,0 +1,195 @@
+package services_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/errorsx"
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
+)
+
+func TestMediaService_UploadMediaFrom(t *testing.T) {
+	content := bytes.Repeat([]byte("%PDF-1.7 "), 200)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v20.0/1234567890/media" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		f, h, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("form file: %v", err)
+		}
+		got, _ := io.ReadAll(f)
+		if !bytes.Equal(got, content) || h.Filename != "report.pdf" || h.Header.Get("Content-Type") != "application/pdf" {
+			t.Fatalf("unexpected upload %q %v (%d bytes)", h.Filename, h.Header, len(got))
+		}
+		if r.FormValue("messaging_product") != "whatsapp" {
+			t.Fatalf("missing messaging_product")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"media-1"}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	res, err := c.Media.UploadMediaFrom(context.Background(), bytes.NewReader(content), "report.pdf", "application/pdf")
+	if err != nil {
+		t.Fatalf("UploadMediaFrom error: %v", err)
+	}
+	if res.Id != "media-1" {
+		t.Fatalf("unexpected id %q", res.Id)
+	}
+}
+
+func TestMediaService_UploadMediaFromUnseekableGetsGraphError(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"error":{"message":"Service temporarily unavailable","code":2}}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	// A pipe-like reader: no Seek, so the upload body cannot be rewound.
+	r := io.MultiReader(strings.NewReader("video bytes"))
+	_, err := c.Media.UploadMediaFrom(context.Background(), r, "clip.mp4", "video/mp4")
+	var he *errorsx.HTTPError
+	if !errors.As(err, &he) || he.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected the 503 HTTPError, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("unseekable upload sent %d times", calls)
+	}
+}
+
+func TestMediaService_DownloadTo(t *testing.T) {
+	content := bytes.Repeat([]byte("media"), 1000)
+	sum := sha256.Sum256(content)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Fatalf("missing auth header")
+		}
+		_, _ = w.Write(content)
+	}))
+	defer srv.Close()
+
+	link := func() *domain.DownloadLinkURL {
+		return &domain.DownloadLinkURL{Url: srv.URL + "/media", Sha256: hex.EncodeToString(sum[:]), FileSize: len(content)}
+	}
+
+	c := newTestClient(t, srv.URL)
+	var buf bytes.Buffer
+	n, err := c.Media.DownloadTo(context.Background(), link(), &buf)
+	if err != nil {
+		t.Fatalf("DownloadTo error: %v", err)
+	}
+	if n != int64(len(content)) || !bytes.Equal(buf.Bytes(), content) {
+		t.Fatalf("unexpected content (%d bytes)", n)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*domain.DownloadLinkURL)
+		max    int64
+		field  string
+	}{
+		{"sha mismatch", func(d *domain.DownloadLinkURL) { d.Sha256 = strings.Repeat("0", 64) }, 0, "sha256"},
+		{"size mismatch", func(d *domain.DownloadLinkURL) { d.FileSize = 10 }, 0, "file_size"},
+		{"advertised too large", nil, 100, "max_size"},
+		{"body too large", func(d *domain.DownloadLinkURL) { d.FileSize = 0 }, 100, "max_size"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, srv.URL)
+			c.Media.WithMaxDownloadSize(tt.max)
+			d := link()
+			if tt.modify != nil {
+				tt.modify(d)
+			}
+			_, err := c.Media.DownloadTo(context.Background(), d, io.Discard)
+			var ie *errorsx.IntegrityError
+			if !errors.As(err, &ie) || ie.Field != tt.field {
+				t.Fatalf("expected IntegrityError on %s, got %v", tt.field, err)
+			}
+		})
+	}
+}
+
+// memoryFile is a minimal ports.FileManagerAPI keeping the data in memory.
+type memoryFile struct{ data []byte }
+
+func (m *memoryFile) SetData(ctx context.Context, data []byte) error {
+	m.data = append([]byte(nil), data...)
+	return nil
+}
+func (m *memoryFile) Save(ctx context.Context, fileName string) error { return nil }
+func (m *memoryFile) Open(ctx context.Context, fileName string) ([]byte, error) {
+	return m.data, nil
+}
+
+func TestMediaService_GetMedia_AllTypes(t *testing.T) {
+	content := []byte("binary-media")
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v20.0/media-1":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"url":"` + srv.URL + `/download","mime_type":"application/octet-stream","sha256":"","file_size":12,"id":"media-1","messaging_product":"whatsapp"}`))
+		case "/download":
+			_, _ = w.Write(content)
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	caption, filename := "invoice", "invoice.pdf"
+	obj := &domain.MediaObject{ID: "media-1", MIMEType: "application/pdf", Caption: &caption, Filename: &filename}
+
+	c := newTestClient(t, srv.URL)
+	c.Media.WithTimeouts(time.Second, time.Second)
+	for _, typ := range []string{"image", "audio", "video", "document", "sticker"} {
+		t.Run(typ, func(t *testing.T) {
+			m := domain.InboundMessage{ID: "wamid", Type: typ}
+			switch typ {
+			case "image":
+				m.Image = obj
+			case "audio":
+				m.Audio = obj
+			case "video":
+				m.Video = obj
+			case "document":
+				m.Document = obj
+			case "sticker":
+				m.Sticker = obj
+			}
+			fm := &memoryFile{}
+			res, err := c.Media.GetMedia(context.Background(), m, fm)
+			if err != nil {
+				t.Fatalf("GetMedia error: %v", err)
+			}
+			if res.Type != typ || res.MIMEType != "application/pdf" || *res.Filename != filename || *res.Caption != caption || res.FileSize != 12 {
+				t.Fatalf("metadata not preserved: %+v", res)
+			}
+			if !bytes.Equal(fm.data, content) || res.File != fm {
+				t.Fatalf("content not stored: %q", fm.data)
+			}
+		})
+	}
+
+	var ve *errorsx.ValidationError
+	if _, err := c.Media.GetMedia(context.Background(), domain.InboundMessage{Type: "text"}, &memoryFile{}); !errors.As(err, &ve) {
+		t.Fatalf("expected ValidationError for text message, got %v", err)
+	}
+}
@@ -2,10 +2,12 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 
 	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/errorsx"
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
 	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/ports"
 	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/transport/graph"
 )
@@ -13,6 +15,58 @@ import (
 // NewMessagesService creates a new MessagesService bound to a minimal client interface.
 func NewMessagesService(c clientCore) *MessagesService { return &MessagesService{c: c} }
 
+// WithTemplateValidator makes Send check template messages with v (typically a
+// *TemplateValidator) before they reach the API. Pass nil to disable.
+func (s *MessagesService) WithTemplateValidator(v TemplateSendValidator) *MessagesService {
+	s.tv = v
+	return s
+}
+
+// WithLimiter makes Send wait for l (typically a *ThroughputLimiter) before
+// each message, so bursts are queued instead of hitting Cloud API rate limits.
+// Pass nil to disable.
+func (s *MessagesService) WithLimiter(l SendLimiter) *MessagesService {
+	s.lim = l
+	return s
+}
+
+// Send validates and sends any prebuilt message payload (see the domain.NewSend*
+// constructors) and decodes the Graph response. The typed Send* helpers are thin
+// wrappers around it.
+func (s *MessagesService) Send(ctx context.Context, payload *domain.SendMessage) (*domain.MessageSendResponse, error) {
+	if payload == nil {
+		return nil, &errorsx.ValidationError{Op: "Send", Field: "payload", Reason: "nil"}
+	}
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+	if s.tv != nil {
+		if err := s.tv.ValidateTemplate(ctx, payload); err != nil {
+			return nil, err
+		}
+	}
+
+	done := func(error) {}
+	if s.lim != nil {
+		var err error
+		if done, err = s.lim.Wait(ctx, s.c.PhoneNumberID(), payload.To); err != nil {
+			return nil, err
+		}
+	}
+
+	b, err := s.doRequest(ctx, payload)
+	done(err)
+	if err != nil {
+		return nil, err
+	}
+
+	var out domain.MessageSendResponse
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil, fmt.Errorf("%w: %w", errDecodeResponse, err)
+	}
+	return &out, nil
+}
+
 func (s *MessagesService) doRequest(ctx context.Context, payload ports.SendMessage) ([]byte, error) {
 	base := s.c.BaseURL()
 	if base == "" {
@@ -33,18 +87,12 @@ func (s *MessagesService) doRequest(ctx context.Context, payload ports.SendMessa
 	}
 	defer resp.Body.Close()
 
+	if err := graph.CheckResponse(resp); err != nil {
+		return nil, err
+	}
 	b, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("read response: %w", err)
 	}
-
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		ge := errorsx.TryParseGraphError(resp, b)
-		if ge != nil {
-			return nil, ge
-		}
-		return nil, errorsx.NewHTTPErrorFromResponse(resp, b)
-	}
-
 	return b, nil
 }
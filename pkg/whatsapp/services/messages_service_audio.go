@@ -0,0 +1,15 @@
+package services
+
+import (
+	"context"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
+)
+
+// SendAudio sends an audio message referencing either an uploaded media ID or a public link.
+func (s *MessagesService) SendAudio(ctx context.Context, to, audioId, audioURL string) (*domain.MessageSendResponse, error) {
+	return s.Send(ctx, domain.NewSendAudioRequest(to, audioId, audioURL))
+}
+func (s *MessagesService) SendAudioReply(ctx context.Context, to, audioId, audioURL, targetMessageId string) (*domain.MessageSendResponse, error) {
+	return s.Send(ctx, domain.NewSendContextAudioRequest(to, audioId, audioURL, targetMessageId))
+}
@@ -0,0 +1,15 @@
+package services
+
+import (
+	"context"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
+)
+
+// SendContacts sends one or more contact cards.
+func (s *MessagesService) SendContacts(ctx context.Context, to string, contacts []*domain.Contact) (*domain.MessageSendResponse, error) {
+	return s.Send(ctx, domain.NewSendContactRequest(to, contacts))
+}
+func (s *MessagesService) SendContactsReply(ctx context.Context, to string, contacts []*domain.Contact, targetMessageId string) (*domain.MessageSendResponse, error) {
+	return s.Send(ctx, domain.NewSendContextContactRequest(to, targetMessageId, contacts))
+}
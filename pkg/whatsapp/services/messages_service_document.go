@@ -0,0 +1,15 @@
+package services
+
+import (
+	"context"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
+)
+
+// SendDocument sends a document message; caption and fileName are optional.
+func (s *MessagesService) SendDocument(ctx context.Context, to, documentId, documentURL, caption, fileName string) (*domain.MessageSendResponse, error) {
+	return s.Send(ctx, domain.NewSendDocumentRequest(to, documentId, documentURL, caption, fileName))
+}
+func (s *MessagesService) SendDocumentReply(ctx context.Context, to, documentId, documentURL, caption, fileName, targetMessageId string) (*domain.MessageSendResponse, error) {
+	return s.Send(ctx, domain.NewSendContextDocumentRequest(to, documentId, documentURL, caption, fileName, targetMessageId))
+}
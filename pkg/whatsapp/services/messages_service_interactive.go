@@ -0,0 +1,24 @@
+package services
+
+import (
+	"context"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
+)
+
+// SendInteractiveButtons sends up to three quick-reply buttons. header and footer
+// are optional; the tapped button id arrives later as an inbound button_reply.
+func (s *MessagesService) SendInteractiveButtons(ctx context.Context, to string, header *domain.InteractiveHeader, body, footer string, buttons []*domain.InteractiveButton) (*domain.MessageSendResponse, error) {
+	return s.Send(ctx, domain.NewSendInteractiveButtonsRequest(to, header, body, footer, buttons))
+}
+func (s *MessagesService) SendInteractiveButtonsReply(ctx context.Context, to string, header *domain.InteractiveHeader, body, footer string, buttons []*domain.InteractiveButton, targetMessageId string) (*domain.MessageSendResponse, error) {
+	return s.Send(ctx, domain.NewSendContextInteractiveButtonsRequest(to, header, body, footer, buttons, targetMessageId))
+}
+
+// SendInteractiveList sends a list message; buttonText labels the button that opens the list.
+func (s *MessagesService) SendInteractiveList(ctx context.Context, to string, header *domain.InteractiveHeader, body, footer, buttonText string, sections []*domain.InteractiveSection) (*domain.MessageSendResponse, error) {
+	return s.Send(ctx, domain.NewSendInteractiveListRequest(to, header, body, footer, buttonText, sections))
+}
+func (s *MessagesService) SendInteractiveListReply(ctx context.Context, to string, header *domain.InteractiveHeader, body, footer, buttonText string, sections []*domain.InteractiveSection, targetMessageId string) (*domain.MessageSendResponse, error) {
+	return s.Send(ctx, domain.NewSendContextInteractiveListRequest(to, header, body, footer, buttonText, sections, targetMessageId))
+}
@@ -0,0 +1,15 @@
+package services
+
+import (
+	"context"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
+)
+
+// SendLocation sends a location pin. Latitude and longitude are decimal strings.
+func (s *MessagesService) SendLocation(ctx context.Context, to, latitude, longitude, name, address string) (*domain.MessageSendResponse, error) {
+	return s.Send(ctx, domain.NewSendLocationRequest(to, latitude, longitude, name, address))
+}
+func (s *MessagesService) SendLocationReply(ctx context.Context, to, latitude, longitude, name, address, targetMessageId string) (*domain.MessageSendResponse, error) {
+	return s.Send(ctx, domain.NewSendContextLocationRequest(to, latitude, longitude, name, address, targetMessageId))
+}
@@ -0,0 +1,29 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
+)
+
+// MarkAsRead sends the read receipt for messageID (a wamid received via webhook).
+// When withTyping is true, a typing indicator is shown to the user as well.
+func (s *MessagesService) MarkAsRead(ctx context.Context, messageID string, withTyping bool) (*domain.ActionResult, error) {
+	payload := domain.NewMarkReadRequest(messageID, withTyping)
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	b, err := s.doRequest(ctx, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var out domain.ActionResult
+	if err = json.Unmarshal(b, &out); err != nil {
+		return nil, fmt.Errorf("decode success response: %w", err)
+	}
+	return &out, nil
+}
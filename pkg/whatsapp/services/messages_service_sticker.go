@@ -0,0 +1,15 @@
+package services
+
+import (
+	"context"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
+)
+
+// SendSticker sends a sticker message (static or animated WebP).
+func (s *MessagesService) SendSticker(ctx context.Context, to, stickerId, stickerURL string) (*domain.MessageSendResponse, error) {
+	return s.Send(ctx, domain.NewSendStickerRequest(to, stickerId, stickerURL))
+}
+func (s *MessagesService) SendStickerReply(ctx context.Context, to, stickerId, stickerURL, targetMessageId string) (*domain.MessageSendResponse, error) {
+	return s.Send(ctx, domain.NewSendContextStickerRequest(to, stickerId, stickerURL, targetMessageId))
+}
@@ -0,0 +1,16 @@
+package services
+
+import (
+	"context"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
+)
+
+// SendTemplate sends an approved message template. Components carry the
+// header/body/button parameters expected by the template definition.
+func (s *MessagesService) SendTemplate(ctx context.Context, to, templateName, templateLang string, components []*domain.TemplateComponent) (*domain.MessageSendResponse, error) {
+	return s.Send(ctx, domain.NewSendTemplateRequest(to, templateName, templateLang, components))
+}
+func (s *MessagesService) SendTemplateReply(ctx context.Context, to, templateName, templateLang string, components []*domain.TemplateComponent, targetMessageId string) (*domain.MessageSendResponse, error) {
+	return s.Send(ctx, domain.NewSendContextTemplateRequest(to, templateName, templateLang, targetMessageId, components))
+}
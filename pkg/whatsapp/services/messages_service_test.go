@@ -15,6 +15,7 @@ import (
 
 	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/errorsx"
 	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp"
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
 	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/services"
 )
 
@@ -33,6 +34,7 @@ func newTestClient(t *testing.T, baseURL string) *whatsapp.Client {
 	opts := whatsapp.Options{
 		Version:         "v20.0",
 		WABAID:          "waba-test",
+		AppID:           "app-test",
 		PhoneNumberID:   "1234567890",
 		HTTPDoer:        nil, // use default httpx with retry
 		TokenProvider:   fakeTokenProvider{token: "test-token"},
@@ -163,3 +165,153 @@ func TestMessagesService_SendText_Retry429Then200(t *testing.T) {
 		t.Fatalf("expected at least 2 calls (429 then 200), got %d", calls)
 	}
 }
+
+func TestMessagesService_TypedSends(t *testing.T) {
+	fixturePath := filepath.Join("..", "..", "..", "testdata", "send_text_success.json")
+	successBody, err := os.ReadFile(fixturePath)
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+
+	var captured map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		captured = nil
+		_ = json.NewDecoder(r.Body).Decode(&captured)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(successBody)
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts.URL)
+	svc := services.NewMessagesService(c)
+	ctx := context.Background()
+	to := "+5511999999999"
+	contacts := []*domain.Contact{{Name: &domain.ContactName{FirstName: "John"}}}
+	text := "hi"
+	components := []*domain.TemplateComponent{{Type: "body", Parameters: []*domain.TemplateParameter{{Type: "text", Text: &text}}}}
+
+	cases := []struct {
+		name     string
+		wantType string
+		reply    bool
+		send     func() (*domain.MessageSendResponse, error)
+	}{
+		{"image link", "image", false, func() (*domain.MessageSendResponse, error) { return svc.SendImage(ctx, to, "", "https://x/a.jpg") }},
+		{"audio", "audio", false, func() (*domain.MessageSendResponse, error) { return svc.SendAudio(ctx, to, "media-1", "") }},
+		{"audio reply", "audio", true, func() (*domain.MessageSendResponse, error) {
+			return svc.SendAudioReply(ctx, to, "media-1", "", "wamid.1")
+		}},
+		{"document", "document", false, func() (*domain.MessageSendResponse, error) {
+			return svc.SendDocument(ctx, to, "", "https://x/a.pdf", "cap", "a.pdf")
+		}},
+		{"document reply", "document", true, func() (*domain.MessageSendResponse, error) {
+			return svc.SendDocumentReply(ctx, to, "media-1", "", "cap", "a.pdf", "wamid.1")
+		}},
+		{"video", "video", false, func() (*domain.MessageSendResponse, error) { return svc.SendVideo(ctx, to, "media-1", "", "cap") }},
+		{"video reply", "video", true, func() (*domain.MessageSendResponse, error) {
+			return svc.SendVideoReply(ctx, to, "", "https://x/a.mp4", "cap", "wamid.1")
+		}},
+		{"sticker", "sticker", false, func() (*domain.MessageSendResponse, error) { return svc.SendSticker(ctx, to, "media-1", "") }},
+		{"sticker reply", "sticker", true, func() (*domain.MessageSendResponse, error) {
+			return svc.SendStickerReply(ctx, to, "media-1", "", "wamid.1")
+		}},
+		{"contacts", "contacts", false, func() (*domain.MessageSendResponse, error) { return svc.SendContacts(ctx, to, contacts) }},
+		{"contacts reply", "contacts", true, func() (*domain.MessageSendResponse, error) {
+			return svc.SendContactsReply(ctx, to, contacts, "wamid.1")
+		}},
+		{"location", "location", false, func() (*domain.MessageSendResponse, error) {
+			return svc.SendLocation(ctx, to, "-23.5", "-46.6", "Office", "Av. Paulista")
+		}},
+		{"location reply", "location", true, func() (*domain.MessageSendResponse, error) {
+			return svc.SendLocationReply(ctx, to, "-23.5", "-46.6", "Office", "Av. Paulista", "wamid.1")
+		}},
+		{"template", "template", false, func() (*domain.MessageSendResponse, error) {
+			return svc.SendTemplate(ctx, to, "hello_world", "en_US", components)
+		}},
+		{"template reply", "template", true, func() (*domain.MessageSendResponse, error) {
+			return svc.SendTemplateReply(ctx, to, "hello_world", "en_US", components, "wamid.1")
+		}},
+		{"interactive buttons", "interactive", false, func() (*domain.MessageSendResponse, error) {
+			return svc.SendInteractiveButtons(ctx, to, nil, "Pick", "", []*domain.InteractiveButton{domain.NewInteractiveReplyButton("a", "A")})
+		}},
+		{"interactive list reply", "interactive", true, func() (*domain.MessageSendResponse, error) {
+			return svc.SendInteractiveListReply(ctx, to, nil, "Pick", "", "Open",
+				[]*domain.InteractiveSection{{Rows: []*domain.InteractiveRow{{ID: "r1", Title: "Row"}}}}, "wamid.1")
+		}},
+		{"reaction", "reaction", false, func() (*domain.MessageSendResponse, error) { return svc.SendEmojiReply(ctx, to, "wamid.1", "👍") }},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := tc.send()
+			if err != nil {
+				t.Fatalf("send: %v", err)
+			}
+			if resp == nil || len(resp.Messages) == 0 {
+				t.Fatalf("expected message id in response, got %+v", resp)
+			}
+			if captured["type"] != tc.wantType {
+				t.Fatalf("unexpected type: want %s got %v", tc.wantType, captured["type"])
+			}
+			if _, ok := captured[tc.wantType]; !ok {
+				t.Fatalf("missing %q payload: %+v", tc.wantType, captured)
+			}
+			if _, ok := captured["context"]; ok != tc.reply {
+				t.Fatalf("context presence: want %v got %v", tc.reply, ok)
+			}
+		})
+	}
+}
+
+func TestMessagesService_Send_Validation(t *testing.T) {
+	c := newTestClient(t, "http://invalid.local")
+	svc := services.NewMessagesService(c)
+	if _, err := svc.Send(context.Background(), nil); err == nil {
+		t.Fatalf("expected validation error for nil payload")
+	}
+	if _, err := svc.SendAudio(context.Background(), "+5511999999999", "", ""); err == nil {
+		t.Fatalf("expected validation error for missing audio reference")
+	}
+	var ve *errorsx.ValidationError
+	_, err := svc.SendLocation(context.Background(), "+5511999999999", "", "", "", "")
+	if !errors.As(err, &ve) {
+		t.Fatalf("expected ValidationError, got %T: %v", err, err)
+	}
+}
+
+func TestMessagesService_MarkAsRead(t *testing.T) {
+	var captured map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		captured = nil
+		_ = json.NewDecoder(r.Body).Decode(&captured)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"success":true}`))
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts.URL)
+	svc := services.NewMessagesService(c)
+
+	out, err := svc.MarkAsRead(context.Background(), "wamid.1", true)
+	if err != nil || !out.Success {
+		t.Fatalf("MarkAsRead: out=%+v err=%v", out, err)
+	}
+	if captured["status"] != "read" || captured["message_id"] != "wamid.1" {
+		t.Fatalf("unexpected payload: %+v", captured)
+	}
+	if ti, ok := captured["typing_indicator"].(map[string]any); !ok || ti["type"] != "text" {
+		t.Fatalf("typing indicator missing: %+v", captured)
+	}
+
+	if _, err := svc.MarkAsRead(context.Background(), "wamid.2", false); err != nil {
+		t.Fatalf("MarkAsRead: %v", err)
+	}
+	if _, ok := captured["typing_indicator"]; ok {
+		t.Fatalf("typing indicator should be omitted: %+v", captured)
+	}
+
+	if _, err := svc.MarkAsRead(context.Background(), "", false); err == nil {
+		t.Fatalf("expected validation error for empty message id")
+	}
+}
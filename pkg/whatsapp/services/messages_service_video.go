@@ -0,0 +1,15 @@
+package services
+
+import (
+	"context"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
+)
+
+// SendVideo sends a video message with an optional caption.
+func (s *MessagesService) SendVideo(ctx context.Context, to, videoId, videoURL, caption string) (*domain.MessageSendResponse, error) {
+	return s.Send(ctx, domain.NewSendVideoRequest(to, videoId, videoURL, caption))
+}
+func (s *MessagesService) SendVideoReply(ctx context.Context, to, videoId, videoURL, caption, targetMessageId string) (*domain.MessageSendResponse, error) {
+	return s.Send(ctx, domain.NewSendContextVideoRequest(to, videoId, videoURL, caption, targetMessageId))
+}
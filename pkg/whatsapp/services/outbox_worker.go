@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/errorsx"
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/ports"
+)
+
+// Defaults of OutboxWorker.
+const (
+	DefaultOutboxBatch        = 10
+	DefaultOutboxLease        = time.Minute
+	DefaultOutboxPollInterval = time.Second
+	DefaultOutboxMaxAttempts  = 5
+	DefaultOutboxRetryBackoff = 5 * time.Second
+	maxOutboxRetryBackoff     = 10 * time.Minute
+)
+
+// OutboxWorker drains a ports.OutboxStore through a MessageSender (typically
+// *MessagesService). Items are claimed under a lease, sent, and marked sent or
+// failed; transient failures are rescheduled with exponential backoff until
+// the attempt budget is spent. Several workers may share one store.
+//
+// Delivery is at-least-once. Transport errors (a refused connection, a reset,
+// a timeout) are retried like transient Graph errors, although the API may
+// have accepted the message before the connection failed; the same happens if
+// the process stops between the send and MarkSent, once the lease expires.
+// Callers that cannot tolerate such duplicates should deduplicate on the
+// item's idempotency key. A 2xx response that could not be decoded was
+// accepted, and is marked sent. Only permanent errors (validation, non
+// transient Graph codes and 4xx statuses) fail an item right away.
+type OutboxWorker struct {
+	store ports.OutboxStore
+	s     MessageSender
+
+	batch       int
+	lease       time.Duration
+	poll        time.Duration
+	maxAttempts int
+	backoff     time.Duration
+	onResult    func(domain.OutboxItem, error)
+}
+
+func NewOutboxWorker(store ports.OutboxStore, s MessageSender) *OutboxWorker {
+	return &OutboxWorker{
+		store:       store,
+		s:           s,
+		batch:       DefaultOutboxBatch,
+		lease:       DefaultOutboxLease,
+		poll:        DefaultOutboxPollInterval,
+		maxAttempts: DefaultOutboxMaxAttempts,
+		backoff:     DefaultOutboxRetryBackoff,
+	}
+}
+
+// WithBatch sets how many items are claimed at once and the lease they are
+// claimed for. The lease must comfortably exceed the time to send a batch.
+// Zero values keep the current setting.
+func (w *OutboxWorker) WithBatch(n int, lease time.Duration) *OutboxWorker {
+	if n > 0 {
+		w.batch = n
+	}
+	if lease > 0 {
+		w.lease = lease
+	}
+	return w
+}
+
+// WithPollInterval sets how long Run waits when the outbox has nothing due.
+func (w *OutboxWorker) WithPollInterval(d time.Duration) *OutboxWorker {
+	if d > 0 {
+		w.poll = d
+	}
+	return w
+}
+
+// WithRetry sets the attempt budget per item and the delay before the first
+// retry, which doubles on each further retry. Zero values keep the current setting.
+func (w *OutboxWorker) WithRetry(maxAttempts int, backoff time.Duration) *OutboxWorker {
+	if maxAttempts > 0 {
+		w.maxAttempts = maxAttempts
+	}
+	if backoff > 0 {
+		w.backoff = backoff
+	}
+	return w
+}
+
+// WithOnResult registers fn to be called after each send attempt with the
+// item as claimed and the send error (nil on success).
+func (w *OutboxWorker) WithOnResult(fn func(domain.OutboxItem, error)) *OutboxWorker {
+	w.onResult = fn
+	return w
+}
+
+// Run drains the outbox until ctx is done, polling when nothing is due. A send
+// in flight when ctx ends is completed, not aborted. It returns ctx.Err(), or
+// the first error returned by the store.
+func (w *OutboxWorker) Run(ctx context.Context) error {
+	for {
+		n, err := w.Drain(ctx)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			if err := sleepCtx(ctx, w.poll); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Drain processes items until none is due and returns how many were handled.
+func (w *OutboxWorker) Drain(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		items, err := w.store.Claim(ctx, w.batch, w.lease)
+		if err != nil {
+			return total, fmt.Errorf("outbox claim: %w", err)
+		}
+		if len(items) == 0 {
+			return total, nil
+		}
+		for _, it := range items {
+			if err := w.process(ctx, it); err != nil {
+				return total, err
+			}
+			total++
+		}
+	}
+}
+
+func (w *OutboxWorker) process(ctx context.Context, it domain.OutboxItem) error {
+	// Sends and outcomes are not cut short by ctx: aborting a request Graph may
+	// already have accepted would leave its outcome unknown.
+	rctx := context.WithoutCancel(ctx)
+	if err := ctx.Err(); err != nil {
+		// Not attempted: release the lease right away.
+		if ferr := w.store.MarkFailed(rctx, it.Key, err.Error(), time.Now()); ferr != nil {
+			log.Printf("outbox: release %s: %v", it.Key, ferr)
+		}
+		return err
+	}
+
+	resp, err := w.s.Send(rctx, it.Message)
+	if w.onResult != nil {
+		w.onResult(it, err)
+	}
+	if err == nil || errors.Is(err, errDecodeResponse) {
+		wamid := ""
+		if err != nil {
+			log.Printf("outbox: %s accepted, %v", it.Key, err)
+		} else if len(resp.Messages) > 0 {
+			wamid = resp.Messages[0].ID
+		}
+		if err := w.store.MarkSent(rctx, it.Key, wamid); err != nil {
+			return fmt.Errorf("outbox mark sent %s: %w", it.Key, err)
+		}
+		return nil
+	}
+
+	var retryAt time.Time
+	if it.Attempts < w.maxAttempts && outboxRetryable(err) {
+		wait := min(w.backoff<<min(it.Attempts-1, 20), maxOutboxRetryBackoff)
+		retryAt = time.Now().Add(retryWait(err, wait))
+	}
+	if ferr := w.store.MarkFailed(rctx, it.Key, err.Error(), retryAt); ferr != nil {
+		return fmt.Errorf("outbox mark failed %s: %w", it.Key, ferr)
+	}
+	return nil
+}
+
+// outboxRetryable reports whether a failed send is worth repeating: anything
+// but a permanent error, including transport errors whose outcome is unknown.
+func outboxRetryable(err error) bool {
+	return errorsx.IsRetryable(err) || !errorsx.IsPermanent(err)
+}
@@ -0,0 +1,139 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/services"
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/storage/outbox"
+)
+
+func enqueueText(t *testing.T, s *outbox.Memory, key, to string) {
+	t.Helper()
+	if _, err := s.Enqueue(context.Background(), domain.OutboxItem{Key: key, Message: domain.NewSendTextMessage(to, key)}); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+}
+
+func TestOutboxWorker_Drain(t *testing.T) {
+	ctx := context.Background()
+	store := outbox.NewMemory()
+	enqueueText(t, store, "ok", "a")
+	enqueueText(t, store, "retry", "retry")
+	enqueueText(t, store, "bad", "bad")
+	enqueueText(t, store, "flaky", "flaky")
+
+	f := &fakeSender{fail: func(to string, call int) error {
+		switch to {
+		case "retry":
+			if call == 1 {
+				return graphCodeError(130429)
+			}
+		case "bad":
+			return graphCodeError(131026)
+		case "flaky":
+			return graphCodeError(131000) // transient, but never recovers
+		}
+		return nil
+	}}
+	var results int
+	w := services.NewOutboxWorker(store, f).WithRetry(2, time.Millisecond).
+		WithOnResult(func(domain.OutboxItem, error) { results++ })
+
+	for i := 0; i < 5; i++ {
+		if _, err := w.Drain(ctx); err != nil {
+			t.Fatalf("Drain: %v", err)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	want := map[string]domain.OutboxStatus{"ok": domain.OutboxSent, "retry": domain.OutboxSent, "bad": domain.OutboxFailed, "flaky": domain.OutboxFailed}
+	for key, st := range want {
+		it, err := store.Get(ctx, key)
+		if err != nil || it.Status != st {
+			t.Fatalf("%s = %+v, %v; want status %s", key, it, err, st)
+		}
+	}
+	if it, _ := store.Get(ctx, "retry"); it.WAMID != "wamid.retry.1" || it.Attempts != 2 {
+		t.Fatalf("retry item = %+v", it)
+	}
+	if it, _ := store.Get(ctx, "bad"); it.Attempts != 1 || it.LastError == "" {
+		t.Fatalf("permanent failure retried: %+v", it)
+	}
+	if it, _ := store.Get(ctx, "flaky"); it.Attempts != 2 {
+		t.Fatalf("attempt budget not applied: %+v", it)
+	}
+	if results != 6 {
+		t.Fatalf("expected 6 send attempts, got %d", results)
+	}
+}
+
+func TestOutboxWorker_RunStopsOnCancel(t *testing.T) {
+	store := outbox.NewMemory()
+	enqueueText(t, store, "k", "a")
+	ctx, cancel := context.WithCancel(context.Background())
+
+	w := services.NewOutboxWorker(store, &fakeSender{}).WithPollInterval(5 * time.Millisecond).
+		WithOnResult(func(domain.OutboxItem, error) { cancel() })
+	errc := make(chan error, 1)
+	go func() { errc <- w.Run(ctx) }()
+
+	select {
+	case err := <-errc:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("Run = %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Run did not stop")
+	}
+	if it, _ := store.Get(context.Background(), "k"); it.Status != domain.OutboxSent {
+		t.Fatalf("send outcome lost on cancel: %+v", it)
+	}
+}
+
+func TestOutboxWorker_UnknownOutcomes(t *testing.T) {
+	ctx := context.Background()
+	store := outbox.NewMemory()
+	enqueueText(t, store, "outage", "a")
+
+	// A transport error is retried within the attempt budget (at-least-once).
+	f := &fakeSender{fail: func(_ string, call int) error {
+		if call == 1 {
+			return errors.New("dial tcp: connection refused")
+		}
+		return nil
+	}}
+	w := services.NewOutboxWorker(store, f).WithRetry(5, time.Millisecond)
+	if _, err := w.Drain(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if it, _ := store.Get(ctx, "outage"); it.Status != domain.OutboxPending || it.Attempts != 1 || it.LastError == "" {
+		t.Fatalf("transport failure not rescheduled: %+v", it)
+	}
+	time.Sleep(5 * time.Millisecond)
+	if _, err := w.Drain(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if it, _ := store.Get(ctx, "outage"); it.Status != domain.OutboxSent || it.Attempts != 2 {
+		t.Fatalf("transport failure not retried: %+v", it)
+	}
+
+	// A 2xx whose body cannot be decoded was accepted by Graph.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "<html>")
+	}))
+	defer srv.Close()
+	enqueueText(t, store, "garbled", "5511999990000")
+	if _, err := services.NewOutboxWorker(store, newTestClient(t, srv.URL).Messages).Drain(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if it, _ := store.Get(ctx, "garbled"); it.Status != domain.OutboxSent || it.Attempts != 1 {
+		t.Fatalf("accepted message not marked sent: %+v", it)
+	}
+}
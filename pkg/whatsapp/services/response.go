@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/transport/graph"
+)
+
+// errDecodeResponse marks a 2xx response whose body could not be decoded: the
+// request took effect, only its result is unknown.
+var errDecodeResponse = errors.New("decode success response")
+
+// doJSON executes req through the client and decodes a 2xx JSON body into out
+// (skipped when out is nil). Non-2xx responses are returned as *errorsx.GraphError
+// or *errorsx.HTTPError by graph.CheckResponse.
+func doJSON(ctx context.Context, c clientCore, req *http.Request, out any) error {
+	resp, err := c.Do(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := graph.CheckResponse(resp); err != nil {
+		return err
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response: %w", err)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(b, out); err != nil {
+		return fmt.Errorf("%w: %w", errDecodeResponse, err)
+	}
+	return nil
+}
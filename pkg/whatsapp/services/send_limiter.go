@@ -0,0 +1,270 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/errorsx"
+)
+
+// SendLimiter paces MessagesService.Send. Wait blocks until a message from
+// phoneNumberID to the recipient to may be sent; the returned done func must be
+// called with the outcome of the send so the limiter can adapt.
+type SendLimiter interface {
+	Wait(ctx context.Context, phoneNumberID, to string) (done func(error), err error)
+}
+
+// Defaults of ThroughputLimiter.
+const (
+	DefaultThroughputRate = 80              // messages per second, the Cloud API default per phone number
+	DefaultPairSpacing    = 6 * time.Second // Meta's pair rate: one message per recipient every 6s
+	DefaultPairPenalty    = 6 * time.Second
+	DefaultRecoverEvery   = 5 * time.Second
+)
+
+// ThroughputLimiterOptions configures a ThroughputLimiter. Zero values select
+// the defaults noted on each field.
+type ThroughputLimiterOptions struct {
+	// Rate is the number of messages per second allowed per phone number.
+	// Default DefaultThroughputRate; raise it for numbers upgraded to 1000 msg/s.
+	Rate float64
+	// Burst is how many messages may be sent at once after an idle period. Default Rate.
+	Burst int
+	// MinRate is the floor the adaptive slow-down never goes below. Default 1.
+	MinRate float64
+	// PairSpacing is the minimum gap between two messages to the same
+	// recipient; messages are queued, never rejected. Default
+	// DefaultPairSpacing; a negative value disables it, except for messages
+	// held back by a pair rate limit, which are still spaced by PairPenalty.
+	PairSpacing time.Duration
+	// PairPenalty is how long a recipient is held back after a pair rate
+	// limit error (131056). Default DefaultPairPenalty.
+	PairPenalty time.Duration
+	// RecoverEvery is how often the rate grows back by 10% towards Rate once
+	// throughput errors stop. Default DefaultRecoverEvery.
+	RecoverEvery time.Duration
+}
+
+// ThroughputLimiter is a SendLimiter enforcing WhatsApp rate rules: a token
+// bucket per phone number and a spacing per recipient. On a throughput error
+// (130429) it halves the phone number's rate, then slowly recovers; on a pair
+// rate limit (131056) it holds that recipient back for PairPenalty.
+type ThroughputLimiter struct {
+	opts ThroughputLimiterOptions
+
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+	next    map[string]time.Time // earliest next send per phone number + recipient
+	sweepAt int
+}
+
+var _ SendLimiter = (*ThroughputLimiter)(nil)
+
+// NewThroughputLimiter returns a ThroughputLimiter configured by o.
+func NewThroughputLimiter(o ThroughputLimiterOptions) *ThroughputLimiter {
+	if o.Rate <= 0 {
+		o.Rate = DefaultThroughputRate
+	}
+	if o.Burst <= 0 {
+		o.Burst = max(1, int(o.Rate))
+	}
+	if o.MinRate <= 0 || o.MinRate > o.Rate {
+		o.MinRate = min(1, o.Rate)
+	}
+	if o.PairSpacing == 0 {
+		o.PairSpacing = DefaultPairSpacing
+	}
+	if o.PairPenalty <= 0 {
+		o.PairPenalty = DefaultPairPenalty
+	}
+	if o.RecoverEvery <= 0 {
+		o.RecoverEvery = DefaultRecoverEvery
+	}
+	return &ThroughputLimiter{
+		opts:    o,
+		buckets: map[string]*tokenBucket{},
+		next:    map[string]time.Time{},
+		sweepAt: 1024,
+	}
+}
+
+// Wait blocks until both the recipient spacing and the phone number's bucket
+// allow a send, or ctx is done.
+func (l *ThroughputLimiter) Wait(ctx context.Context, phoneNumberID, to string) (func(error), error) {
+	key := phoneNumberID + "|" + to
+	wait, release := l.reservePair(key, time.Now())
+	if err := sleepCtx(ctx, wait); err != nil {
+		release()
+		return nil, err
+	}
+
+	b := l.bucket(phoneNumberID)
+	if err := sleepCtx(ctx, b.reserve(time.Now())); err != nil {
+		b.cancel()
+		release()
+		return nil, err
+	}
+	return func(err error) { l.observe(b, key, err) }, nil
+}
+
+// Rate returns the current, possibly reduced, rate for phoneNumberID.
+func (l *ThroughputLimiter) Rate(phoneNumberID string) float64 {
+	b := l.bucket(phoneNumberID)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.rate
+}
+
+func (l *ThroughputLimiter) bucket(phoneNumberID string) *tokenBucket {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b, ok := l.buckets[phoneNumberID]
+	if !ok {
+		b = &tokenBucket{rate: l.opts.Rate, burst: float64(l.opts.Burst), tokens: float64(l.opts.Burst), last: time.Now()}
+		l.buckets[phoneNumberID] = b
+	}
+	return b
+}
+
+// reservePair books the next slot for key and returns how long to wait for
+// it, and a func giving the slot back when the send is abandoned.
+func (l *ThroughputLimiter) reservePair(key string, now time.Time) (time.Duration, func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	prev, had := l.next[key]
+	start := now
+	if had && prev.After(now) {
+		start = prev
+	}
+	spacing := l.opts.PairSpacing
+	if spacing <= 0 {
+		if !start.After(now) {
+			return 0, func() {}
+		}
+		// Held back by a penalty: release the queued messages one by one
+		// rather than all at once when it ends.
+		spacing = l.opts.PairPenalty
+	}
+	booked := start.Add(spacing)
+	l.next[key] = booked
+	l.sweep(now)
+	return start.Sub(now), func() { l.releasePair(key, booked, prev, had) }
+}
+
+// releasePair undoes a reservePair that booked key up to booked. It does
+// nothing once a later reservation or a penalty moved the slot: those were
+// computed from this booking and keep their place.
+func (l *ThroughputLimiter) releasePair(key string, booked, prev time.Time, had bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.next[key].Equal(booked) {
+		return
+	}
+	if had {
+		l.next[key] = prev
+	} else {
+		delete(l.next, key)
+	}
+}
+
+// sweep drops expired recipient entries once the map has grown.
+func (l *ThroughputLimiter) sweep(now time.Time) {
+	if len(l.next) < l.sweepAt {
+		return
+	}
+	for k, t := range l.next {
+		if !t.After(now) {
+			delete(l.next, k)
+		}
+	}
+	l.sweepAt = max(1024, 2*len(l.next))
+}
+
+func (l *ThroughputLimiter) observe(b *tokenBucket, key string, err error) {
+	now := time.Now()
+	switch {
+	case err == nil:
+		b.recover(now, l.opts.Rate, l.opts.RecoverEvery)
+	case errors.Is(err, errorsx.ErrThroughputLimit):
+		b.slowDown(now, l.opts.MinRate)
+	case errors.Is(err, errorsx.ErrPairRateLimit):
+		l.mu.Lock()
+		if until := now.Add(l.opts.PairPenalty); l.next[key].Before(until) {
+			l.next[key] = until
+		}
+		l.mu.Unlock()
+	}
+}
+
+// tokenBucket hands out reservations, so waiters are served in arrival order.
+type tokenBucket struct {
+	mu      sync.Mutex
+	rate    float64 // tokens per second
+	burst   float64
+	tokens  float64 // negative when reservations are queued
+	last    time.Time
+	changed time.Time // last adaptive rate change
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if el := now.Sub(b.last).Seconds(); el > 0 {
+		b.tokens = min(b.burst, b.tokens+el*b.rate)
+		b.last = now
+	}
+}
+
+// reserve takes a token and returns how long to wait before using it.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill(now)
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel returns a token whose reservation was abandoned.
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	b.tokens++
+	b.mu.Unlock()
+}
+
+func (b *tokenBucket) slowDown(now time.Time, floor float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill(now)
+	b.rate = max(floor, b.rate/2)
+	b.tokens = min(b.tokens, 0) // drop the burst
+	b.changed = now
+}
+
+func (b *tokenBucket) recover(now time.Time, ceiling float64, every time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.rate >= ceiling || now.Sub(b.changed) < every {
+		return
+	}
+	b.refill(now)
+	b.rate = min(ceiling, b.rate*1.1)
+	b.changed = now
+}
+
+// sleepCtx waits for d or until ctx is done.
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
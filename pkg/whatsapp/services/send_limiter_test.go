@@ -0,0 +1,204 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/errorsx"
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/services"
+)
+
+const sendOK = `{"messaging_product":"whatsapp","contacts":[{"input":"x","wa_id":"x"}],"messages":[{"id":"wamid.1"}]}`
+
+func TestThroughputLimiter_PacesPerPhoneNumber(t *testing.T) {
+	l := services.NewThroughputLimiter(services.ThroughputLimiterOptions{Rate: 50, Burst: 1, PairSpacing: -1})
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		done, err := l.Wait(ctx, "pn-1", "5511999999999")
+		if err != nil {
+			t.Fatalf("Wait: %v", err)
+		}
+		done(nil)
+	}
+	// 1 immediate + 5 x 20ms.
+	if el := time.Since(start); el < 90*time.Millisecond {
+		t.Fatalf("expected pacing at 50/s, 6 sends took %v", el)
+	}
+
+	// Another phone number has its own bucket.
+	start = time.Now()
+	done, err := l.Wait(ctx, "pn-2", "5511999999999")
+	if err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	done(nil)
+	if el := time.Since(start); el > 10*time.Millisecond {
+		t.Fatalf("pn-2 should not wait on pn-1, waited %v", el)
+	}
+}
+
+func TestThroughputLimiter_PairSpacingQueues(t *testing.T) {
+	l := services.NewThroughputLimiter(services.ThroughputLimiterOptions{Rate: 1000, PairSpacing: 30 * time.Millisecond})
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			done, err := l.Wait(ctx, "pn", "same")
+			if err != nil {
+				t.Errorf("Wait: %v", err)
+				return
+			}
+			done(nil)
+		}()
+	}
+	wg.Wait()
+	if el := time.Since(start); el < 55*time.Millisecond {
+		t.Fatalf("3 messages to one recipient should span 2 gaps, took %v", el)
+	}
+}
+
+func TestThroughputLimiter_WaitHonorsContext(t *testing.T) {
+	l := services.NewThroughputLimiter(services.ThroughputLimiterOptions{Rate: 1, Burst: 1})
+	if _, err := l.Wait(context.Background(), "pn", "a"); err != nil {
+		t.Fatalf("first Wait: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := l.Wait(ctx, "pn", "b"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestThroughputLimiter_CanceledWaitReleasesPairSlot(t *testing.T) {
+	l := services.NewThroughputLimiter(services.ThroughputLimiterOptions{Rate: 1000, PairSpacing: 100 * time.Millisecond})
+	if _, err := l.Wait(context.Background(), "pn", "same"); err != nil {
+		t.Fatalf("first Wait: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := l.Wait(ctx, "pn", "same"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected DeadlineExceeded, got %v", err)
+	}
+
+	// The abandoned slot is free again: the next send follows the first one.
+	start := time.Now()
+	if _, err := l.Wait(context.Background(), "pn", "same"); err != nil {
+		t.Fatalf("third Wait: %v", err)
+	}
+	if el := time.Since(start); el > 150*time.Millisecond {
+		t.Fatalf("canceled reservation still held, waited %v", el)
+	}
+}
+
+func TestThroughputLimiter_SpacesQueuedSendsAfterPenalty(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		spacing time.Duration
+		gap     time.Duration // expected minimum gap between the queued sends
+	}{
+		{"spacing", 30 * time.Millisecond, 30 * time.Millisecond},
+		{"spacing disabled", -1, 60 * time.Millisecond}, // spaced by the penalty
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			l := services.NewThroughputLimiter(services.ThroughputLimiterOptions{Rate: 1000, PairSpacing: tc.spacing, PairPenalty: 60 * time.Millisecond})
+			ctx := context.Background()
+			done, err := l.Wait(ctx, "pn", "same")
+			if err != nil {
+				t.Fatalf("Wait: %v", err)
+			}
+			start := time.Now()
+			done(errorsx.ErrPairRateLimit)
+
+			var (
+				mu    sync.Mutex
+				times []time.Duration
+				wg    sync.WaitGroup
+			)
+			for i := 0; i < 3; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					done, err := l.Wait(ctx, "pn", "same")
+					if err != nil {
+						t.Errorf("Wait: %v", err)
+						return
+					}
+					mu.Lock()
+					times = append(times, time.Since(start))
+					mu.Unlock()
+					done(nil)
+				}()
+			}
+			wg.Wait()
+			slices.Sort(times)
+			if len(times) != 3 || times[0] < 55*time.Millisecond {
+				t.Fatalf("sends not held back by the penalty: %v", times)
+			}
+			for i := 1; i < len(times); i++ {
+				if gap := times[i] - times[i-1]; gap < tc.gap-5*time.Millisecond {
+					t.Fatalf("queued sends fired together after the penalty: %v", times)
+				}
+			}
+		})
+	}
+}
+
+func TestMessagesService_LimiterAdaptsToRateLimitErrors(t *testing.T) {
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch calls.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":{"message":"(#130429) Rate limit hit","type":"OAuthException","code":130429}}`))
+		case 2:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":{"message":"(#131056) Pair rate limit hit","type":"OAuthException","code":131056}}`))
+		default:
+			w.Write([]byte(sendOK))
+		}
+	}))
+	defer ts.Close()
+
+	l := services.NewThroughputLimiter(services.ThroughputLimiterOptions{Rate: 100, PairSpacing: -1, PairPenalty: 60 * time.Millisecond})
+	svc := services.NewMessagesService(newTestClient(t, ts.URL)).WithLimiter(l)
+	ctx := context.Background()
+
+	if _, err := svc.SendText(ctx, "+5511999999999", "a"); !errors.Is(err, errorsx.ErrThroughputLimit) {
+		t.Fatalf("expected throughput error, got %v", err)
+	}
+	if r := l.Rate("1234567890"); r != 50 {
+		t.Fatalf("expected rate halved to 50, got %v", r)
+	}
+
+	if _, err := svc.SendText(ctx, "+5511999999999", "b"); !errors.Is(err, errorsx.ErrPairRateLimit) {
+		t.Fatalf("expected pair rate limit error, got %v", err)
+	}
+	start := time.Now()
+	if _, err := svc.SendText(ctx, "+5511999999999", "c"); err != nil {
+		t.Fatalf("SendText: %v", err)
+	}
+	if el := time.Since(start); el < 50*time.Millisecond {
+		t.Fatalf("expected recipient held back after 131056, waited %v", el)
+	}
+	start = time.Now()
+	if _, err := svc.SendText(ctx, "+5511888888888", "d"); err != nil {
+		t.Fatalf("SendText: %v", err)
+	}
+	if el := time.Since(start); el > 40*time.Millisecond {
+		t.Fatalf("other recipients must not be penalized, waited %v", el)
+	}
+}
@@ -0,0 +1,320 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/ports"
+)
+
+// ErrMessageFailed is returned by StatusTracker.Await when the message failed
+// before reaching the awaited status. The error also wraps the first
+// *domain.WebhookError of the failed status, when present.
+var ErrMessageFailed = errors.New("message failed")
+
+// DefaultStatusTTL is how long the default in-memory store keeps a message
+// after its last update.
+const DefaultStatusTTL = 72 * time.Hour
+
+// StatusObserver consumes message statuses; *StatusTracker satisfies it.
+type StatusObserver interface {
+	ObserveStatus(ctx context.Context, s domain.MessageStatus)
+}
+
+// StatusTracker correlates sent messages (wamids) with the statuses later
+// delivered by webhooks. Statuses may arrive out of order, or even before the
+// send is recorded: the tracked status only ever advances (sent < delivered <
+// read), and failed is terminal.
+//
+// Updates to one wamid are serialized within one tracker, while different
+// wamids are stored concurrently; share one tracker per store.
+type StatusTracker struct {
+	store    ports.StatusStore
+	onFailed func(domain.TrackedMessage, *domain.WebhookError)
+
+	// locks serialize the read-modify-write of a wamid in the store; a wamid
+	// always maps to the same stripe. mu only guards waiters, and is taken
+	// after a stripe, never before.
+	locks   [statusLockStripes]sync.Mutex
+	mu      sync.Mutex
+	waiters map[string][]*statusWaiter
+}
+
+// statusLockStripes is the number of per-wamid locks of a StatusTracker.
+const statusLockStripes = 64
+
+type statusWaiter struct {
+	rank int
+	ch   chan domain.TrackedMessage // buffered, receives once
+}
+
+var _ StatusObserver = (*StatusTracker)(nil)
+
+// NewStatusTracker returns a tracker persisting to store, or to an in-memory
+// store keeping messages for DefaultStatusTTL when store is nil.
+func NewStatusTracker(store ports.StatusStore) *StatusTracker {
+	if store == nil {
+		store = newMemoryStatusStore(DefaultStatusTTL)
+	}
+	return &StatusTracker{store: store, waiters: map[string][]*statusWaiter{}}
+}
+
+// WithOnFailed registers fn to be called once when a message fails. err is the
+// first error reported by the webhook, or nil when it carried none.
+func (t *StatusTracker) WithOnFailed(fn func(m domain.TrackedMessage, err *domain.WebhookError)) *StatusTracker {
+	t.onFailed = fn
+	return t
+}
+
+// Track records a sent message. Statuses already received for wamid are kept.
+func (t *StatusTracker) Track(ctx context.Context, wamid, recipientID string) error {
+	if wamid == "" {
+		return fmt.Errorf("track: empty wamid")
+	}
+	l := t.lock(wamid)
+	defer l.Unlock()
+	m, _, err := t.store.Get(ctx, wamid)
+	if err != nil {
+		return fmt.Errorf("track %s: %w", wamid, err)
+	}
+	m.WAMID, m.Tracked, m.UpdatedAt = wamid, true, time.Now()
+	if m.RecipientID == "" {
+		m.RecipientID = recipientID
+	}
+	return t.store.Put(ctx, m)
+}
+
+// Sender wraps s so that every successful send is tracked.
+func (t *StatusTracker) Sender(s MessageSender) MessageSender { return trackingSender{s: s, t: t} }
+
+type trackingSender struct {
+	s MessageSender
+	t *StatusTracker
+}
+
+func (ts trackingSender) Send(ctx context.Context, m *domain.SendMessage) (*domain.MessageSendResponse, error) {
+	resp, err := ts.s.Send(ctx, m)
+	if err != nil || len(resp.Messages) == 0 {
+		return resp, err
+	}
+	to := m.To
+	if len(resp.Contacts) > 0 && resp.Contacts[0].WaID != "" {
+		to = resp.Contacts[0].WaID
+	}
+	if terr := ts.t.Track(ctx, resp.Messages[0].ID, to); terr != nil {
+		log.Printf("status tracker: %v", terr)
+	}
+	return resp, nil
+}
+
+// ObserveStatus applies a webhook status. Errors from the store are logged, as
+// webhook handlers have no caller to report them to.
+func (t *StatusTracker) ObserveStatus(ctx context.Context, s domain.MessageStatus) {
+	if err := t.Observe(ctx, s); err != nil {
+		log.Printf("status tracker: %v", err)
+	}
+}
+
+// Observe applies a webhook status and wakes the matching Await calls.
+func (t *StatusTracker) Observe(ctx context.Context, s domain.MessageStatus) error {
+	if s.ID == "" {
+		return nil
+	}
+	l := t.lock(s.ID)
+	m, _, err := t.store.Get(ctx, s.ID)
+	if err != nil {
+		l.Unlock()
+		return fmt.Errorf("observe %s: %w", s.ID, err)
+	}
+	wasFailed := m.Status == domain.MessageStatusFailed
+	m.WAMID = s.ID
+	if m.RecipientID == "" {
+		m.RecipientID = s.RecipientID
+	}
+	// Copy: the previous map may be shared with values handed out earlier.
+	ts := maps.Clone(m.Timestamps)
+	if ts == nil {
+		ts = map[string]string{}
+	}
+	ts[s.Status] = s.Timestamp
+	m.Timestamps = ts
+	if domain.StatusRank(s.Status) > domain.StatusRank(m.Status) {
+		m.Status = s.Status
+		if s.Status == domain.MessageStatusFailed {
+			m.Errors = s.Errors
+		}
+	}
+	m.UpdatedAt = time.Now()
+	if err := t.store.Put(ctx, m); err != nil {
+		l.Unlock()
+		return fmt.Errorf("observe %s: %w", s.ID, err)
+	}
+	t.mu.Lock()
+	t.wake(m)
+	t.mu.Unlock()
+	l.Unlock()
+
+	if !wasFailed && m.Status == domain.MessageStatusFailed && t.onFailed != nil {
+		t.onFailed(m, firstWebhookError(m))
+	}
+	return nil
+}
+
+// Get returns the tracked state of wamid.
+func (t *StatusTracker) Get(ctx context.Context, wamid string) (domain.TrackedMessage, bool, error) {
+	return t.store.Get(ctx, wamid)
+}
+
+// Await blocks until wamid reaches status (or a later one, e.g. read satisfies
+// delivered), and returns its state. It fails with ErrMessageFailed if the
+// message fails first, and with ctx.Err() when ctx is done.
+func (t *StatusTracker) Await(ctx context.Context, wamid, status string) (domain.TrackedMessage, error) {
+	rank := domain.StatusRank(status)
+	if rank == 0 {
+		return domain.TrackedMessage{}, fmt.Errorf("await %s: unknown status %q", wamid, status)
+	}
+
+	// Holding the wamid's lock from the check to the registration means no
+	// Observe can slip in between and leave the waiter unwoken.
+	l := t.lock(wamid)
+	m, _, err := t.store.Get(ctx, wamid)
+	if err != nil {
+		l.Unlock()
+		return m, fmt.Errorf("await %s: %w", wamid, err)
+	}
+	if satisfied(m, rank) {
+		l.Unlock()
+		return m, awaitErr(m, rank)
+	}
+	w := &statusWaiter{rank: rank, ch: make(chan domain.TrackedMessage, 1)}
+	t.mu.Lock()
+	t.waiters[wamid] = append(t.waiters[wamid], w)
+	t.mu.Unlock()
+	l.Unlock()
+
+	select {
+	case m := <-w.ch:
+		return m, awaitErr(m, rank)
+	case <-ctx.Done():
+		t.mu.Lock()
+		t.removeWaiter(wamid, w)
+		t.mu.Unlock()
+		return m, ctx.Err()
+	}
+}
+
+// lock locks and returns the stripe of wamid.
+func (t *StatusTracker) lock(wamid string) *sync.Mutex {
+	h := fnv.New32a()
+	h.Write([]byte(wamid))
+	l := &t.locks[h.Sum32()%statusLockStripes]
+	l.Lock()
+	return l
+}
+
+// wake notifies the waiters satisfied by m. Must hold t.mu.
+func (t *StatusTracker) wake(m domain.TrackedMessage) {
+	ws := t.waiters[m.WAMID]
+	kept := ws[:0]
+	for _, w := range ws {
+		if satisfied(m, w.rank) {
+			w.ch <- m
+			continue
+		}
+		kept = append(kept, w)
+	}
+	if len(kept) == 0 {
+		delete(t.waiters, m.WAMID)
+	} else {
+		t.waiters[m.WAMID] = kept
+	}
+}
+
+func (t *StatusTracker) removeWaiter(wamid string, w *statusWaiter) {
+	ws := t.waiters[wamid]
+	for i, x := range ws {
+		if x == w {
+			t.waiters[wamid] = append(ws[:i], ws[i+1:]...)
+			break
+		}
+	}
+	if len(t.waiters[wamid]) == 0 {
+		delete(t.waiters, wamid)
+	}
+}
+
+// satisfied reports whether m ended waiting for rank: it reached it, or failed.
+func satisfied(m domain.TrackedMessage, rank int) bool {
+	return domain.StatusRank(m.Status) >= rank
+}
+
+func awaitErr(m domain.TrackedMessage, rank int) error {
+	if m.Status != domain.MessageStatusFailed || rank == domain.StatusRank(domain.MessageStatusFailed) {
+		return nil
+	}
+	if we := firstWebhookError(m); we != nil {
+		return fmt.Errorf("%w: %s: %w", ErrMessageFailed, m.WAMID, we)
+	}
+	return fmt.Errorf("%w: %s", ErrMessageFailed, m.WAMID)
+}
+
+func firstWebhookError(m domain.TrackedMessage) *domain.WebhookError {
+	if len(m.Errors) == 0 {
+		return nil
+	}
+	we := m.Errors[0]
+	return &we
+}
+
+// memoryStatusStore is the default StatusStore. Messages not updated for ttl
+// are dropped lazily as new ones are stored.
+type memoryStatusStore struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	msgs    map[string]domain.TrackedMessage
+	sweepAt int
+}
+
+func newMemoryStatusStore(ttl time.Duration) *memoryStatusStore {
+	return &memoryStatusStore{ttl: ttl, msgs: map[string]domain.TrackedMessage{}, sweepAt: 1024}
+}
+
+func (s *memoryStatusStore) Get(ctx context.Context, wamid string) (domain.TrackedMessage, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m, ok := s.msgs[wamid]
+	if ok && time.Since(m.UpdatedAt) > s.ttl {
+		delete(s.msgs, wamid)
+		return domain.TrackedMessage{}, false, nil
+	}
+	return m, ok, nil
+}
+
+func (s *memoryStatusStore) Put(ctx context.Context, m domain.TrackedMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.msgs[m.WAMID] = m
+	if len(s.msgs) >= s.sweepAt {
+		for k, v := range s.msgs {
+			if time.Since(v.UpdatedAt) > s.ttl {
+				delete(s.msgs, k)
+			}
+		}
+		s.sweepAt = max(1024, 2*len(s.msgs))
+	}
+	return nil
+}
+
+func (s *memoryStatusStore) Delete(ctx context.Context, wamid string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.msgs, wamid)
+	return nil
+}
@@ -0,0 +1,221 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/errorsx"
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/services"
+)
+
+func status(id, st, ts string, errs ...domain.WebhookError) domain.MessageStatus {
+	return domain.MessageStatus{ID: id, Status: st, Timestamp: ts, RecipientID: "5511999999999", Errors: errs}
+}
+
+func TestStatusTracker_OutOfOrderStatuses(t *testing.T) {
+	ctx := context.Background()
+	tr := services.NewStatusTracker(nil)
+
+	// The read status arrives before the send is even recorded.
+	if err := tr.Observe(ctx, status("wamid.1", "read", "30")); err != nil {
+		t.Fatalf("Observe: %v", err)
+	}
+	if err := tr.Track(ctx, "wamid.1", "5511999999999"); err != nil {
+		t.Fatalf("Track: %v", err)
+	}
+	tr.Observe(ctx, status("wamid.1", "sent", "10"))
+	tr.Observe(ctx, status("wamid.1", "delivered", "20"))
+
+	m, ok, err := tr.Get(ctx, "wamid.1")
+	if err != nil || !ok {
+		t.Fatalf("Get: %v %v", ok, err)
+	}
+	if m.Status != "read" || !m.Tracked || len(m.Timestamps) != 3 || m.Timestamps["sent"] != "10" {
+		t.Fatalf("unexpected state: %+v", m)
+	}
+}
+
+func TestStatusTracker_Await(t *testing.T) {
+	ctx := context.Background()
+	tr := services.NewStatusTracker(nil)
+	tr.Track(ctx, "wamid.1", "5511999999999")
+
+	got := make(chan error, 1)
+	go func() {
+		_, err := tr.Await(ctx, "wamid.1", "delivered")
+		got <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	tr.Observe(ctx, status("wamid.1", "sent", "1"))
+	select {
+	case err := <-got:
+		t.Fatalf("Await returned early: %v", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+	// A later status satisfies an earlier one.
+	tr.Observe(ctx, status("wamid.1", "read", "2"))
+	select {
+	case err := <-got:
+		if err != nil {
+			t.Fatalf("Await: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Await not woken")
+	}
+
+	// Already satisfied: returns immediately.
+	if m, err := tr.Await(ctx, "wamid.1", "sent"); err != nil || m.Status != "read" {
+		t.Fatalf("Await = %+v, %v", m, err)
+	}
+
+	cctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if _, err := tr.Await(cctx, "wamid.2", "sent"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected DeadlineExceeded, got %v", err)
+	}
+	if _, err := tr.Await(ctx, "wamid.1", "bogus"); err == nil {
+		t.Fatalf("expected error for unknown status")
+	}
+}
+
+func TestStatusTracker_Failure(t *testing.T) {
+	ctx := context.Background()
+	var (
+		failed []string
+		cause  *domain.WebhookError
+	)
+	tr := services.NewStatusTracker(nil).WithOnFailed(func(m domain.TrackedMessage, err *domain.WebhookError) {
+		failed = append(failed, m.WAMID)
+		cause = err
+	})
+	tr.Track(ctx, "wamid.1", "5511999999999")
+
+	got := make(chan error, 1)
+	go func() {
+		_, err := tr.Await(ctx, "wamid.1", "read")
+		got <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	we := domain.WebhookError{Code: 131047, Title: "Re-engagement message", ErrorData: &domain.WebhookErrorData{Details: "24h window closed"}}
+	tr.Observe(ctx, status("wamid.1", "failed", "5", we))
+	tr.Observe(ctx, status("wamid.1", "failed", "6", we)) // duplicate webhook
+	tr.Observe(ctx, status("wamid.1", "delivered", "4"))  // late, must not revive it
+
+	err := <-got
+	if !errors.Is(err, services.ErrMessageFailed) || !errors.Is(err, errorsx.ErrReengagementWindow) {
+		t.Fatalf("Await error = %v", err)
+	}
+	var typed *domain.WebhookError
+	if !errors.As(err, &typed) || typed.ErrorData.Details != "24h window closed" {
+		t.Fatalf("WebhookError not reachable: %v", err)
+	}
+	if len(failed) != 1 || cause == nil || cause.Code != 131047 {
+		t.Fatalf("failure callback = %v %+v", failed, cause)
+	}
+	if m, _, _ := tr.Get(ctx, "wamid.1"); m.Status != "failed" {
+		t.Fatalf("failed status overwritten: %+v", m)
+	}
+	if _, err := tr.Await(ctx, "wamid.1", "failed"); err != nil {
+		t.Fatalf("awaiting failed itself should succeed: %v", err)
+	}
+}
+
+func TestStatusTracker_SenderAndDispatcher(t *testing.T) {
+	ctx := context.Background()
+	tr := services.NewStatusTracker(nil)
+	s := tr.Sender(&fakeSender{})
+
+	resp, err := s.Send(ctx, domain.NewSendTextMessage("5511999999999", "hi"))
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	wamid := resp.Messages[0].ID
+	if m, ok, _ := tr.Get(ctx, wamid); !ok || !m.Tracked {
+		t.Fatalf("send not tracked: %+v", m)
+	}
+
+	d := services.NewWebhookDispatcher(&fakeWebhookHandler{}).WithStatusTracker(tr)
+	d.Dispatch(domain.WebhookEvent{Entry: []domain.WebhookEntry{{Changes: []domain.WebhookChange{
+		{Value: domain.WebhookValue{Statuses: []domain.MessageStatus{status(wamid, "delivered", "1")}}},
+	}}}}, nil)
+	if m, _, _ := tr.Get(ctx, wamid); m.Status != "delivered" {
+		t.Fatalf("dispatcher status not observed: %+v", m)
+	}
+}
+
+// gatedStatusStore is a map store whose Get blocks on gate for the wamid slow.
+type gatedStatusStore struct {
+	slow    string
+	gate    chan struct{}
+	entered chan struct{}
+
+	mu   sync.Mutex
+	msgs map[string]domain.TrackedMessage
+}
+
+func (s *gatedStatusStore) Get(_ context.Context, wamid string) (domain.TrackedMessage, bool, error) {
+	if wamid == s.slow {
+		s.entered <- struct{}{}
+		<-s.gate
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m, ok := s.msgs[wamid]
+	return m, ok, nil
+}
+
+func (s *gatedStatusStore) Put(_ context.Context, m domain.TrackedMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.msgs[m.WAMID] = m
+	return nil
+}
+
+func (s *gatedStatusStore) Delete(_ context.Context, wamid string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.msgs, wamid)
+	return nil
+}
+
+func TestStatusTracker_SlowStoreBlocksOnlyItsMessage(t *testing.T) {
+	ctx := context.Background()
+	store := &gatedStatusStore{slow: "wamid.slow", gate: make(chan struct{}), entered: make(chan struct{}, 1), msgs: map[string]domain.TrackedMessage{}}
+	tr := services.NewStatusTracker(store)
+
+	slow := make(chan error)
+	go func() { slow <- tr.Observe(ctx, status("wamid.slow", "sent", "10")) }()
+	<-store.entered
+
+	// Another message is updated and awaited while the slow one is stuck.
+	done := make(chan error)
+	go func() {
+		if err := tr.Observe(ctx, status("wamid.fast", "delivered", "20")); err != nil {
+			done <- err
+			return
+		}
+		_, err := tr.Await(ctx, "wamid.fast", "delivered")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("fast message: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("an update of another message waited on the slow store call")
+	}
+
+	close(store.gate)
+	if err := <-slow; err != nil {
+		t.Fatalf("slow message: %v", err)
+	}
+	if m, _, _ := tr.Get(ctx, "wamid.slow"); m.Status != "sent" {
+		t.Fatalf("slow message state: %+v", m)
+	}
+}
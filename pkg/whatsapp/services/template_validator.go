@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/errorsx"
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
+)
+
+// TemplateLister lists template definitions; *TemplatesService satisfies it.
+type TemplateLister interface {
+	List(ctx context.Context, p domain.TemplateListParams) (*domain.MessageTemplateList, error)
+}
+
+// templateDefinitionFields are the fields ValidateSend needs from the definition.
+var templateDefinitionFields = []string{"id", "name", "language", "status", "category", "parameter_format", "components"}
+
+// TemplateValidator checks outgoing template messages against their fetched
+// definition so mismatches fail locally with a precise field path instead of a
+// Graph error. Definitions are cached per name/language; ttl <= 0 caches them
+// until Invalidate is called.
+type TemplateValidator struct {
+	l   TemplateLister
+	ttl time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cachedTemplate
+}
+
+type cachedTemplate struct {
+	def     *domain.MessageTemplate
+	expires time.Time
+}
+
+func NewTemplateValidator(l TemplateLister, ttl time.Duration) *TemplateValidator {
+	return &TemplateValidator{l: l, ttl: ttl, cache: map[string]cachedTemplate{}}
+}
+
+// ValidateTemplate validates msg when it is a template message; other message
+// types are accepted as-is.
+func (v *TemplateValidator) ValidateTemplate(ctx context.Context, msg *domain.SendMessage) error {
+	if msg == nil || msg.Type != "template" || msg.TemplateMessage == nil || msg.TemplateMessage.Template == nil {
+		return nil
+	}
+	body := msg.TemplateMessage.Template
+	if body.Language == nil {
+		return &errorsx.ValidationError{Op: "ValidateTemplate", Field: "template.language", Reason: "nil lang"}
+	}
+	def, err := v.Definition(ctx, body.Name, body.Language.Code)
+	if err != nil {
+		return err
+	}
+	return def.ValidateSend(body)
+}
+
+// Definition returns the cached definition for name/language, fetching it on a miss.
+func (v *TemplateValidator) Definition(ctx context.Context, name, language string) (*domain.MessageTemplate, error) {
+	key := name + "/" + language
+
+	v.mu.Lock()
+	e, ok := v.cache[key]
+	v.mu.Unlock()
+	if ok && (e.expires.IsZero() || time.Now().Before(e.expires)) {
+		return e.def, nil
+	}
+
+	def, err := v.fetch(ctx, name, language)
+	if err != nil {
+		return nil, err
+	}
+	e = cachedTemplate{def: def}
+	if v.ttl > 0 {
+		e.expires = time.Now().Add(v.ttl)
+	}
+	v.mu.Lock()
+	v.cache[key] = e
+	v.mu.Unlock()
+	return def, nil
+}
+
+// Invalidate drops the cached definition, e.g. after the template was edited.
+func (v *TemplateValidator) Invalidate(name, language string) {
+	v.mu.Lock()
+	delete(v.cache, name+"/"+language)
+	v.mu.Unlock()
+}
+
+func (v *TemplateValidator) fetch(ctx context.Context, name, language string) (*domain.MessageTemplate, error) {
+	p := domain.TemplateListParams{Name: name, Language: language, Fields: templateDefinitionFields}
+	for {
+		page, err := v.l.List(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		// The name filter is a partial match, so look for the exact template.
+		for i := range page.Data {
+			if page.Data[i].Name == name && page.Data[i].Language == language {
+				return &page.Data[i], nil
+			}
+		}
+		if page.Paging == nil || page.Paging.Next == "" || page.Paging.Cursors == nil || page.Paging.Cursors.After == "" || page.Paging.Cursors.After == p.After {
+			return nil, &errorsx.ValidationError{Op: "ValidateTemplate", Field: "template.name", Reason: "template " + name + " (" + language + ") not found"}
+		}
+		p.After = page.Paging.Cursors.After
+	}
+}
@@ -0,0 +1,79 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/errorsx"
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/services"
+)
+
+func TestTemplateValidator_SendWithCache(t *testing.T) {
+	var lists, sends int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v20.0/waba-test/message_templates":
+			atomic.AddInt32(&lists, 1)
+			if q := r.URL.Query(); q.Get("language") != "en" || q.Get("fields") == "" {
+				t.Fatalf("unexpected filters %s", r.URL.RawQuery)
+			}
+			// The name filter is fuzzy: the exact match is not first.
+			_, _ = w.Write([]byte(`{"data":[
+				{"name":"welcome_back","language":"en","status":"APPROVED","category":"UTILITY","components":[{"type":"BODY","text":"Hi"}]},
+				{"name":"welcome","language":"en","status":"APPROVED","category":"UTILITY","components":[{"type":"BODY","text":"Hi {{1}}"}]}
+			]}`))
+		case "/v20.0/1234567890/messages":
+			atomic.AddInt32(&sends, 1)
+			_, _ = w.Write([]byte(`{"messaging_product":"whatsapp","contacts":[{"input":"5511","wa_id":"5511"}],"messages":[{"id":"wamid.T"}]}`))
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	v := services.NewTemplateValidator(c.Templates, 0)
+	c.Messages.WithTemplateValidator(v)
+
+	name := "Ana"
+	ok := domain.NewSendTemplateRequest("5511", "welcome", "en", []*domain.TemplateComponent{
+		{Type: "body", Parameters: []*domain.TemplateParameter{{Type: "text", Text: &name}}},
+	})
+	if _, err := c.Messages.Send(context.Background(), ok); err != nil {
+		t.Fatalf("Send error: %v", err)
+	}
+
+	bad := domain.NewSendTemplateRequest("5511", "welcome", "en", []*domain.TemplateComponent{
+		{Type: "body", Parameters: []*domain.TemplateParameter{{Type: "text", Text: &name}, {Type: "text", Text: &name}}},
+	})
+	_, err := c.Messages.Send(context.Background(), bad)
+	var ve *errorsx.ValidationError
+	if !errors.As(err, &ve) || ve.Field != "template.components[0].parameters" {
+		t.Fatalf("expected ValidationError on parameters, got %v", err)
+	}
+
+	if lists != 1 {
+		t.Fatalf("definition should be fetched once, got %d", lists)
+	}
+	if sends != 1 {
+		t.Fatalf("invalid message must not be sent, sends=%d", sends)
+	}
+
+	v.Invalidate("welcome", "en")
+	if _, err := v.Definition(context.Background(), "welcome", "en"); err != nil {
+		t.Fatalf("Definition error: %v", err)
+	}
+	if lists != 2 {
+		t.Fatalf("Invalidate should force a refetch, got %d lists", lists)
+	}
+
+	if _, err := v.Definition(context.Background(), "missing", "en"); err == nil {
+		t.Fatalf("expected not found error")
+	}
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/errorsx"
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/transport/graph"
+)
+
+// TemplatesService manages message templates of the WhatsApp Business Account
+// configured on the client (/{WABA-ID}/message_templates).
+type TemplatesService struct {
+	c clientCore
+}
+
+func NewTemplatesService(c clientCore) *TemplatesService { return &TemplatesService{c: c} }
+
+// List returns one page of templates. Use p.After with the returned
+// Paging.Cursors.After to fetch the next page, or ListAll to walk every page.
+func (s *TemplatesService) List(ctx context.Context, p domain.TemplateListParams) (*domain.MessageTemplateList, error) {
+	req, err := graph.NewTemplatesListRequest(ctx, s.c.BaseURL(), s.c.Version(), s.c.WABAID(), p)
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+	var out domain.MessageTemplateList
+	if err := doJSON(ctx, s.c, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListAll follows the "after" cursor until the last page and returns every
+// template matching p. p.After is used as the starting cursor.
+func (s *TemplatesService) ListAll(ctx context.Context, p domain.TemplateListParams) ([]domain.MessageTemplate, error) {
+	var all []domain.MessageTemplate
+	for {
+		page, err := s.List(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page.Data...)
+		if page.Paging == nil || page.Paging.Next == "" || page.Paging.Cursors == nil || page.Paging.Cursors.After == "" {
+			return all, nil
+		}
+		if page.Paging.Cursors.After == p.After {
+			return nil, fmt.Errorf("list templates: paging cursor did not advance")
+		}
+		p.After = page.Paging.Cursors.After
+	}
+}
+
+// Get fetches a single template by its ID.
+func (s *TemplatesService) Get(ctx context.Context, templateID string) (*domain.MessageTemplate, error) {
+	if templateID == "" {
+		return nil, &errorsx.ValidationError{Op: "GetTemplate", Field: "templateID", Reason: "empty"}
+	}
+	req, err := graph.NewTemplateGetRequest(ctx, s.c.BaseURL(), s.c.Version(), templateID)
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+	var out domain.MessageTemplate
+	if err := doJSON(ctx, s.c, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Create validates the definition and submits it for review.
+func (s *TemplatesService) Create(ctx context.Context, t *domain.MessageTemplate) (*domain.MessageTemplateCreateResult, error) {
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	req, err := graph.NewTemplateCreateRequest(ctx, s.c.BaseURL(), s.c.Version(), s.c.WABAID(), t)
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+	var out domain.MessageTemplateCreateResult
+	if err := doJSON(ctx, s.c, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Edit updates the category and/or components of an existing template.
+func (s *TemplatesService) Edit(ctx context.Context, templateID string, e domain.MessageTemplateEdit) (*domain.ActionResult, error) {
+	if templateID == "" {
+		return nil, &errorsx.ValidationError{Op: "EditTemplate", Field: "templateID", Reason: "empty"}
+	}
+	if e.Category == "" && len(e.Components) == 0 {
+		return nil, &errorsx.ValidationError{Op: "EditTemplate", Reason: "nothing to edit"}
+	}
+	req, err := graph.NewTemplateEditRequest(ctx, s.c.BaseURL(), s.c.Version(), templateID, e)
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+	var out domain.ActionResult
+	if err := doJSON(ctx, s.c, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Delete removes a template by name. With hsmID (the template ID) only that
+// language version is removed; otherwise all languages of the name are deleted.
+func (s *TemplatesService) Delete(ctx context.Context, name, hsmID string) (*domain.ActionResult, error) {
+	if name == "" {
+		return nil, &errorsx.ValidationError{Op: "DeleteTemplate", Field: "name", Reason: "empty"}
+	}
+	req, err := graph.NewTemplateDeleteRequest(ctx, s.c.BaseURL(), s.c.Version(), s.c.WABAID(), name, hsmID)
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+	var out domain.ActionResult
+	if err := doJSON(ctx, s.c, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
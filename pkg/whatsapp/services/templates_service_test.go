@@ -0,0 +1,142 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/errorsx"
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
+)
+
+func TestTemplatesService_ListAll(t *testing.T) {
+	page1, err := os.ReadFile(filepath.Join("..", "..", "..", "testdata", "message_templates_list.json"))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	page2 := []byte(`{"data":[{"id":"3","name":"otp","language":"en","status":"APPROVED","category":"AUTHENTICATION","components":[]}],"paging":{"cursors":{"before":"b","after":"c2"}}}`)
+
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Path != "/v20.0/waba-test/message_templates" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("status"); got != "APPROVED" {
+			t.Fatalf("status filter = %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("after") == "c1" {
+			_, _ = w.Write(page2)
+			return
+		}
+		_, _ = w.Write(page1)
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	all, err := c.Templates.ListAll(context.Background(), domain.TemplateListParams{Status: domain.TemplateStatusApproved})
+	if err != nil {
+		t.Fatalf("ListAll error: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 page requests, got %d", calls)
+	}
+	if len(all) != 3 || all[0].Name != "order_update" || all[2].Name != "otp" {
+		t.Fatalf("unexpected templates: %+v", all)
+	}
+	if all[0].Components[1].Example == nil || all[0].Components[1].Example.BodyText[0][0] != "Ana" {
+		t.Fatalf("components not decoded: %+v", all[0].Components)
+	}
+}
+
+func TestTemplatesService_Create(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v20.0/waba-test/message_templates" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var got domain.MessageTemplate
+		b, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(b, &got); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if got.Name != "welcome" || len(got.Components) != 1 {
+			t.Fatalf("unexpected payload: %s", b)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"99","status":"PENDING","category":"UTILITY"}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	tmpl := &domain.MessageTemplate{
+		Name: "welcome", Language: "en", Category: domain.TemplateCategoryUtility,
+		Components: []domain.MessageTemplateComponent{{Type: domain.TemplateComponentBody, Text: "Welcome!"}},
+	}
+	res, err := c.Templates.Create(context.Background(), tmpl)
+	if err != nil {
+		t.Fatalf("Create error: %v", err)
+	}
+	if res.ID != "99" || res.Status != domain.TemplateStatusPending {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+
+	// Invalid definitions never reach the server.
+	tmpl.Components = nil
+	var ve *errorsx.ValidationError
+	if _, err := c.Templates.Create(context.Background(), tmpl); !errors.As(err, &ve) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+}
+
+func TestTemplatesService_EditDelete(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v20.0/123":
+			b, _ := io.ReadAll(r.Body)
+			if string(b) != `{"category":"MARKETING"}` {
+				t.Fatalf("unexpected edit body: %s", b)
+			}
+			_, _ = w.Write([]byte(`{"success":true}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/v20.0/waba-test/message_templates":
+			if r.URL.Query().Get("name") != "welcome" || r.URL.Query().Get("hsm_id") != "123" {
+				t.Fatalf("unexpected delete query %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"success":true}`))
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	if res, err := c.Templates.Edit(context.Background(), "123", domain.MessageTemplateEdit{Category: domain.TemplateCategoryMarketing}); err != nil || !res.Success {
+		t.Fatalf("Edit = %+v, %v", res, err)
+	}
+	if res, err := c.Templates.Delete(context.Background(), "welcome", "123"); err != nil || !res.Success {
+		t.Fatalf("Delete = %+v, %v", res, err)
+	}
+}
+
+func TestTemplatesService_GraphError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"message":"Invalid parameter","type":"OAuthException","code":100,"fbtrace_id":"trace"}}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	_, err := c.Templates.Delete(context.Background(), "missing", "")
+	var ge *errorsx.GraphError
+	if !errors.As(err, &ge) {
+		t.Fatalf("expected GraphError, got %T %v", err, err)
+	}
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/errorsx"
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/transport/graph"
+)
+
+// Defaults of UploadsService.
+const (
+	DefaultUploadChunkSize  int64 = 4 << 20
+	DefaultUploadMaxResumes       = 3
+)
+
+// uploadsCore extends clientCore with the app ID the Resumable Upload API needs.
+type uploadsCore interface {
+	clientCore
+	AppID() string
+}
+
+// UploadsService implements the Graph Resumable Upload API, used for template
+// header samples and business profile pictures. Files are sent in chunks; when
+// a chunk fails on a transient error the server offset is queried and the
+// upload resumes from there.
+type UploadsService struct {
+	c          uploadsCore
+	chunkSize  int64
+	maxResumes int
+}
+
+func NewUploadsService(c uploadsCore) *UploadsService {
+	return &UploadsService{c: c, chunkSize: DefaultUploadChunkSize, maxResumes: DefaultUploadMaxResumes}
+}
+
+// WithChunkSize sets how many bytes are sent per request. n <= 0 restores the default.
+func (s *UploadsService) WithChunkSize(n int64) *UploadsService {
+	if n <= 0 {
+		n = DefaultUploadChunkSize
+	}
+	s.chunkSize = n
+	return s
+}
+
+// WithMaxResumes sets how many times a single Upload/Resume call may resume
+// after a failed chunk. Negative values disable resuming.
+func (s *UploadsService) WithMaxResumes(n int) *UploadsService {
+	s.maxResumes = n
+	return s
+}
+
+// CreateSession opens an upload session for a file of fileLength bytes and
+// MIME type fileType (e.g. "image/jpeg").
+func (s *UploadsService) CreateSession(ctx context.Context, fileName string, fileLength int64, fileType string) (*domain.UploadSession, error) {
+	const op = "CreateUploadSession"
+	if s.c.AppID() == "" {
+		return nil, &errorsx.ValidationError{Op: op, Field: "AppID", Reason: "empty; set Options.AppID"}
+	}
+	if fileLength <= 0 {
+		return nil, &errorsx.ValidationError{Op: op, Field: "fileLength", Reason: "must be positive"}
+	}
+	if fileType == "" {
+		return nil, &errorsx.ValidationError{Op: op, Field: "fileType", Reason: "empty"}
+	}
+
+	req, err := graph.NewUploadSessionRequest(ctx, s.c.BaseURL(), s.c.Version(), s.c.AppID(), fileName, fileLength, fileType)
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+	var out domain.UploadSession
+	if err := doJSON(ctx, s.c, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Status returns the session with the offset the server has received so far.
+func (s *UploadsService) Status(ctx context.Context, sessionID string) (*domain.UploadSession, error) {
+	if sessionID == "" {
+		return nil, &errorsx.ValidationError{Op: "UploadStatus", Field: "sessionID", Reason: "empty"}
+	}
+	req, err := graph.NewUploadStatusRequest(ctx, s.c.BaseURL(), s.c.Version(), sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+	var out domain.UploadSession
+	if err := doJSON(ctx, s.c, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Upload opens a session and sends the size bytes of r, returning the file handle.
+func (s *UploadsService) Upload(ctx context.Context, r io.ReaderAt, size int64, fileName, fileType string) (*domain.UploadHandle, error) {
+	sess, err := s.CreateSession(ctx, fileName, size, fileType)
+	if err != nil {
+		return nil, err
+	}
+	return s.Resume(ctx, sess.ID, r, size)
+}
+
+// Resume continues the session from the offset reported by the server. It can
+// also pick up a session created by an earlier, interrupted process. A session
+// that already received all size bytes cannot be finished: the server only
+// hands out the file handle in answer to the last chunk.
+func (s *UploadsService) Resume(ctx context.Context, sessionID string, r io.ReaderAt, size int64) (*domain.UploadHandle, error) {
+	if size <= 0 {
+		return nil, &errorsx.ValidationError{Op: "ResumeUpload", Field: "size", Reason: "must be positive"}
+	}
+	st, err := s.Status(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	offset := st.FileOffset
+
+	buf := make([]byte, min(s.chunkSize, size))
+	for resumes := 0; ; {
+		if offset > size {
+			return nil, fmt.Errorf("upload %s: server offset %d beyond file size %d", sessionID, offset, size)
+		}
+		if offset == size {
+			// The server has every byte but its handle was lost with the
+			// response to the last chunk; an empty chunk would not bring it back.
+			return nil, fmt.Errorf("upload %s: no file handle after %d bytes", sessionID, size)
+		}
+		chunk := buf[:min(s.chunkSize, size-offset)]
+		if n, err := r.ReadAt(chunk, offset); n < len(chunk) {
+			return nil, fmt.Errorf("read at %d: %w", offset, err)
+		}
+
+		h, err := s.sendChunk(ctx, sessionID, offset, chunk)
+		if err != nil {
+			if ctx.Err() != nil || resumes >= s.maxResumes || !isResumable(err) {
+				return nil, err
+			}
+			resumes++
+			st, serr := s.Status(ctx, sessionID)
+			if serr != nil {
+				return nil, fmt.Errorf("resume after %v: %w", err, serr)
+			}
+			offset = st.FileOffset
+			continue
+		}
+		if h.H != "" {
+			return h, nil
+		}
+
+		offset += int64(len(chunk))
+	}
+}
+
+func (s *UploadsService) sendChunk(ctx context.Context, sessionID string, offset int64, chunk []byte) (*domain.UploadHandle, error) {
+	req, err := graph.NewUploadChunkRequest(ctx, s.c.BaseURL(), s.c.Version(), sessionID, offset, chunk)
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+	var out domain.UploadHandle
+	if err := doJSON(ctx, s.c, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// isResumable reports whether a failed chunk is worth resuming: transport
+// failures (e.g. a dropped connection) and retryable HTTP statuses.
+func isResumable(err error) bool {
+	var he *errorsx.HTTPError
+	if errors.As(err, &he) {
+		return errorsx.IsRetryable(err)
+	}
+	var ve *errorsx.ValidationError
+	return !errors.As(err, &ve)
+}
@@ -0,0 +1,135 @@
+package services_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/errorsx"
+)
+
+// fakeUploadServer stands in for the Resumable Upload API. When dropAtChunk is
+// set, that chunk is half stored and the connection is cut, as a flaky network would.
+type fakeUploadServer struct {
+	t           *testing.T
+	mu          sync.Mutex
+	size        int64
+	received    []byte
+	chunks      int
+	statusCalls int
+	dropAtChunk int
+}
+
+func (f *fakeUploadServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/v20.0/app-test/uploads":
+		q := r.URL.Query()
+		if q.Get("file_name") != "logo.png" || q.Get("file_type") != "image/png" {
+			f.t.Fatalf("unexpected session query %s", r.URL.RawQuery)
+		}
+		f.size, _ = strconv.ParseInt(q.Get("file_length"), 10, 64)
+		_, _ = w.Write([]byte(`{"id":"upload:abc?sig=xyz"}`))
+	case r.URL.Path == "/v20.0/upload:abc" && r.URL.Query().Get("sig") == "xyz":
+		if r.Method == http.MethodGet {
+			f.statusCalls++
+			_, _ = w.Write([]byte(`{"id":"upload:abc","file_offset":` + strconv.Itoa(len(f.received)) + `}`))
+			return
+		}
+		if got := r.Header.Get("file_offset"); got != strconv.Itoa(len(f.received)) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":{"message":"bad offset ` + got + `","code":100}}`))
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		f.chunks++
+		if f.chunks == f.dropAtChunk {
+			f.received = append(f.received, b[:len(b)/2]...)
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				f.t.Fatalf("hijack: %v", err)
+			}
+			_ = conn.Close()
+			return
+		}
+		f.received = append(f.received, b...)
+		if int64(len(f.received)) == f.size {
+			_, _ = w.Write([]byte(`{"h":"2:handle"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	default:
+		f.t.Fatalf("unexpected request %s %s", r.Method, r.URL)
+	}
+}
+
+func TestUploadsService_UploadResumes(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 100)
+	fake := &fakeUploadServer{t: t, dropAtChunk: 2}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	c.Uploads.WithChunkSize(300)
+	h, err := c.Uploads.Upload(context.Background(), bytes.NewReader(content), int64(len(content)), "logo.png", "image/png")
+	if err != nil {
+		t.Fatalf("Upload error: %v", err)
+	}
+	if h.H != "2:handle" {
+		t.Fatalf("unexpected handle %q", h.H)
+	}
+	if !bytes.Equal(fake.received, content) {
+		t.Fatalf("server content differs (%d of %d bytes)", len(fake.received), len(content))
+	}
+	// One status query to start, one to resume after the dropped chunk.
+	if fake.statusCalls != 2 {
+		t.Fatalf("expected the upload to resume once, got %d status calls", fake.statusCalls)
+	}
+}
+
+func TestUploadsService_NoResume(t *testing.T) {
+	fake := &fakeUploadServer{t: t, dropAtChunk: 1}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	c.Uploads.WithChunkSize(10).WithMaxResumes(-1)
+	if _, err := c.Uploads.Upload(context.Background(), bytes.NewReader(make([]byte, 30)), 30, "logo.png", "image/png"); err == nil {
+		t.Fatalf("expected error without resumes")
+	}
+}
+
+func TestUploadsService_CreateSessionValidation(t *testing.T) {
+	c := newTestClient(t, "http://127.0.0.1:0")
+	var ve *errorsx.ValidationError
+	if _, err := c.Uploads.CreateSession(context.Background(), "a.png", 0, "image/png"); !errors.As(err, &ve) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+}
+
+func TestUploadsService_ResumeSendsNoEmptyChunk(t *testing.T) {
+	fake := &fakeUploadServer{t: t, size: 30, received: make([]byte, 30)}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	if _, err := c.Uploads.Resume(context.Background(), "upload:abc?sig=xyz", bytes.NewReader(make([]byte, 30)), 30); err == nil {
+		t.Fatalf("expected error for a fully received session without handle")
+	}
+	var ve *errorsx.ValidationError
+	if _, err := c.Uploads.Resume(context.Background(), "upload:abc?sig=xyz", bytes.NewReader(nil), 0); !errors.As(err, &ve) {
+		t.Fatalf("expected ValidationError for an empty file, got %v", err)
+	}
+	if fake.chunks != 0 || fake.statusCalls != 1 {
+		t.Fatalf("sent %d chunks and %d status queries, want 0 and 1", fake.chunks, fake.statusCalls)
+	}
+}
@@ -1,9 +1,16 @@
 package services
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
+	"sync/atomic"
+	"time"
 
 	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/ports"
 )
 
 type WebhookHandler interface {
@@ -12,24 +19,225 @@ type WebhookHandler interface {
 	OnStatus(s domain.MessageStatus, e domain.WebhookEvent, h http.Header)
 }
 
-type WebhookDispatcher struct{ h WebhookHandler }
+// WebhookHandlerV2 is the context-aware form of WebhookHandler. ctx is the
+// webhook request context (or a background context under AsyncDispatcher).
+// A returned error makes webhook.Handler answer 500 so Meta redelivers the
+// event; with WithDedup only the items that failed run again.
+type WebhookHandlerV2 interface {
+	Always(ctx context.Context, e domain.WebhookEvent, h http.Header) error
+	OnMessage(ctx context.Context, m domain.InboundMessage, e domain.WebhookEvent, h http.Header) error
+	OnStatus(ctx context.Context, s domain.MessageStatus, e domain.WebhookEvent, h http.Header) error
+}
+
+// AdaptWebhookHandler turns a WebhookHandler into a WebhookHandlerV2 that
+// ignores ctx and never fails.
+func AdaptWebhookHandler(h WebhookHandler) WebhookHandlerV2 { return legacyHandler{h} }
+
+type legacyHandler struct{ h WebhookHandler }
+
+func (l legacyHandler) Always(_ context.Context, e domain.WebhookEvent, h http.Header) error {
+	l.h.Always(e, h)
+	return nil
+}
+
+func (l legacyHandler) OnMessage(_ context.Context, m domain.InboundMessage, e domain.WebhookEvent, h http.Header) error {
+	l.h.OnMessage(m, e, h)
+	return nil
+}
+
+func (l legacyHandler) OnStatus(_ context.Context, s domain.MessageStatus, e domain.WebhookEvent, h http.Header) error {
+	l.h.OnStatus(s, e, h)
+	return nil
+}
+
+// ReadMarker sends read receipts; *MessagesService satisfies it.
+type ReadMarker interface {
+	MarkAsRead(ctx context.Context, messageID string, withTyping bool) (*domain.ActionResult, error)
+}
+
+// markReadTimeout bounds each automatic read receipt so a slow Graph call does
+// not hold the webhook response for long.
+const markReadTimeout = 5 * time.Second
+
+// DefaultDedupTTL matches the 7 days during which Meta may redeliver a webhook.
+const DefaultDedupTTL = 7 * 24 * time.Hour
+
+type WebhookDispatcher struct {
+	h WebhookHandlerV2
+
+	reader     ReadMarker
+	withTyping bool
+	statuses   StatusObserver
 
-func NewWebhookDispatcher(h WebhookHandler) *WebhookDispatcher { return &WebhookDispatcher{h: h} }
+	fields *WebhookFieldHandlers
+
+	seen       ports.SeenStore
+	seenTTL    time.Duration
+	duplicates atomic.Uint64
+}
+
+func NewWebhookDispatcher(h WebhookHandler) *WebhookDispatcher {
+	return &WebhookDispatcher{h: AdaptWebhookHandler(h)}
+}
+
+// NewWebhookDispatcherV2 returns a dispatcher for a context-aware handler.
+func NewWebhookDispatcherV2(h WebhookHandlerV2) *WebhookDispatcher { return &WebhookDispatcher{h: h} }
+
+// WithAutoMarkRead makes Dispatch mark every inbound message as read (optionally
+// with a typing indicator) right before OnMessage is called. Failures are logged
+// and never prevent the handler from running.
+func (d *WebhookDispatcher) WithAutoMarkRead(r ReadMarker, withTyping bool) *WebhookDispatcher {
+	d.reader = r
+	d.withTyping = withTyping
+	return d
+}
+
+// WithStatusTracker feeds every status to o (typically a *StatusTracker)
+// before OnStatus is called.
+func (d *WebhookDispatcher) WithStatusTracker(o StatusObserver) *WebhookDispatcher {
+	d.statuses = o
+	return d
+}
+
+// WithFieldHandlers calls f for changes of non-message fields (template,
+// phone number, account, security and user preference updates) after Always.
+func (d *WebhookDispatcher) WithFieldHandlers(f WebhookFieldHandlers) *WebhookDispatcher {
+	d.fields = &f
+	return d
+}
+
+// WithDedup skips messages and statuses already dispatched within ttl
+// (DefaultDedupTTL when ttl <= 0). Messages are keyed on their ID and statuses
+// on their ID and status value, so "delivered" after "sent" still goes
+// through. Skipped deliveries are counted in Duplicates. Items whose handler
+// fails are forgotten so the redelivery runs again. When the store fails the
+// item is dispatched anyway.
+func (d *WebhookDispatcher) WithDedup(store ports.SeenStore, ttl time.Duration) *WebhookDispatcher {
+	if ttl <= 0 {
+		ttl = DefaultDedupTTL
+	}
+	d.seen = store
+	d.seenTTL = ttl
+	return d
+}
 
+// Duplicates returns how many redelivered messages and statuses were skipped.
+func (d *WebhookDispatcher) Duplicates() uint64 { return d.duplicates.Load() }
+
+// Dispatch is DispatchContext without a context; handler errors are logged.
 func (d *WebhookDispatcher) Dispatch(e domain.WebhookEvent, h http.Header) {
-	d.h.Always(e, h)
+	if err := d.DispatchContext(context.Background(), e, h); err != nil {
+		log.Printf("webhook handler: %v", err)
+	}
+}
+
+// DispatchContext calls the handler for the event, each message and each
+// status. A failing call does not stop the others; their errors are joined.
+func (d *WebhookDispatcher) DispatchContext(ctx context.Context, e domain.WebhookEvent, h http.Header) error {
+	return d.dispatch(ctx, e, h, func(f func() error) error { return f() })
+}
+
+// dispatch runs every handler call through call, which lets AsyncDispatcher
+// recover panics per call.
+func (d *WebhookDispatcher) dispatch(ctx context.Context, e domain.WebhookEvent, h http.Header, call func(func() error) error) error {
+	var errs []error
+	if err := call(func() error { return d.h.Always(ctx, e, h) }); err != nil {
+		errs = append(errs, err)
+	}
 	for _, entry := range e.Entry {
 		for _, ch := range entry.Changes {
-			if len(ch.Value.Messages) > 0 {
-				for _, m := range ch.Value.Messages {
-					d.h.OnMessage(m, e, h)
+			if d.fields != nil && ch.Update != nil {
+				if err := call(func() error { return d.fields.dispatch(ctx, ch, e, h) }); err != nil {
+					errs = append(errs, fmt.Errorf("field %s: %w", ch.Field, err))
 				}
 			}
-			if len(ch.Value.Statuses) > 0 {
-				for _, s := range ch.Value.Statuses {
-					d.h.OnStatus(s, e, h)
+			for _, m := range ch.Value.Messages {
+				key := dedupKey("message", m.ID)
+				if !d.firstSeen(ctx, key) {
+					continue
+				}
+				err := call(func() error {
+					d.markRead(ctx, m)
+					return d.h.OnMessage(ctx, m, e, h)
+				})
+				if err != nil {
+					d.forget(ctx, key)
+					errs = append(errs, fmt.Errorf("message %s: %w", m.ID, err))
+				}
+			}
+			for _, s := range ch.Value.Statuses {
+				key := dedupKey("status", s.ID, s.Status)
+				if !d.firstSeen(ctx, key) {
+					continue
+				}
+				err := call(func() error {
+					if d.statuses != nil {
+						d.statuses.ObserveStatus(ctx, s)
+					}
+					return d.h.OnStatus(ctx, s, e, h)
+				})
+				if err != nil {
+					d.forget(ctx, key)
+					errs = append(errs, fmt.Errorf("status %s %s: %w", s.ID, s.Status, err))
 				}
 			}
 		}
 	}
+	return errors.Join(errs...)
+}
+
+// dedupKey joins kind and parts into a SeenStore key; items without an ID get
+// no key and are never deduplicated.
+func dedupKey(kind, id string, parts ...string) string {
+	if id == "" {
+		return ""
+	}
+	key := kind + ":" + id
+	for _, p := range parts {
+		key += ":" + p
+	}
+	return key
+}
+
+// firstSeen reports whether key has not been dispatched yet, counting it as a
+// duplicate otherwise. Without a store or key, or on store failure, it
+// returns true.
+func (d *WebhookDispatcher) firstSeen(ctx context.Context, key string) bool {
+	if d.seen == nil || key == "" {
+		return true
+	}
+	first, err := d.seen.MarkSeen(ctx, key, d.seenTTL)
+	if err != nil {
+		log.Printf("webhook dedup %s: %v", key, err)
+		return true
+	}
+	if !first {
+		d.duplicates.Add(1)
+	}
+	return first
+}
+
+// forget drops key after a failed handler call, so the redelivery is dispatched.
+func (d *WebhookDispatcher) forget(ctx context.Context, key string) {
+	if d.seen == nil || key == "" {
+		return
+	}
+	if err := d.seen.Forget(context.WithoutCancel(ctx), key); err != nil {
+		log.Printf("webhook dedup forget %s: %v", key, err)
+	}
+}
+
+// markRead sends the receipt with the dispatch ctx's values but not its
+// cancellation, so a receipt already under way is not cut short by the webhook
+// request ending. It stays synchronous: the typing indicator has to be sent
+// before the handler replies.
+func (d *WebhookDispatcher) markRead(ctx context.Context, m domain.InboundMessage) {
+	if d.reader == nil || m.ID == "" {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), markReadTimeout)
+	defer cancel()
+	if _, err := d.reader.MarkAsRead(ctx, m.ID, d.withTyping); err != nil {
+		log.Printf("mark as read %s: %v", m.ID, err)
+	}
 }
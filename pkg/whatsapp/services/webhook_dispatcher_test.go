@@ -1,12 +1,17 @@
 package services_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
 	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/services"
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/storage/seen"
 )
 
 type fakeWebhookHandler struct {
@@ -65,3 +70,219 @@ func TestWebhookDispatcher_DispatchEmpty(t *testing.T) {
 		t.Fatalf("expected no statuses, got %d", len(h.statuses))
 	}
 }
+
+type fakeReadMarker struct {
+	ids    []string
+	typing bool
+	err    error
+	ctx    context.Context
+	ctxErr error // ctx.Err() during the call
+}
+
+func (f *fakeReadMarker) MarkAsRead(ctx context.Context, id string, withTyping bool) (*domain.ActionResult, error) {
+	f.ctx, f.ctxErr = ctx, ctx.Err()
+	f.ids = append(f.ids, id)
+	f.typing = withTyping
+	return &domain.ActionResult{Success: f.err == nil}, f.err
+}
+
+func TestWebhookDispatcher_AutoMarkRead(t *testing.T) {
+	event := domain.WebhookEvent{
+		Entry: []domain.WebhookEntry{{
+			Changes: []domain.WebhookChange{
+				{Value: domain.WebhookValue{Messages: []domain.InboundMessage{{ID: "m1"}, {ID: "m2"}}}},
+				{Value: domain.WebhookValue{Statuses: []domain.MessageStatus{{ID: "s1"}}}},
+			},
+		}},
+	}
+
+	h := &fakeWebhookHandler{}
+	rm := &fakeReadMarker{}
+	services.NewWebhookDispatcher(h).WithAutoMarkRead(rm, true).Dispatch(event, http.Header{})
+	if len(rm.ids) != 2 || rm.ids[0] != "m1" || rm.ids[1] != "m2" || !rm.typing {
+		t.Fatalf("unexpected read receipts: %+v", rm)
+	}
+
+	// A failing receipt must not stop the handler.
+	h = &fakeWebhookHandler{}
+	rm = &fakeReadMarker{err: errors.New("boom")}
+	services.NewWebhookDispatcher(h).WithAutoMarkRead(rm, false).Dispatch(event, http.Header{})
+	if len(h.messages) != 2 {
+		t.Fatalf("handler should still receive messages, got %d", len(h.messages))
+	}
+
+	// The receipt carries the dispatch ctx's values, not its cancellation.
+	type key struct{}
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), key{}, "trace"))
+	cancel()
+	rm = &fakeReadMarker{}
+	_ = services.NewWebhookDispatcher(&fakeWebhookHandler{}).WithAutoMarkRead(rm, false).DispatchContext(ctx, event, nil)
+	if rm.ctx.Value(key{}) != "trace" || rm.ctxErr != nil {
+		t.Fatalf("receipt ctx: value %v, err %v", rm.ctx.Value(key{}), rm.ctxErr)
+	}
+	if _, ok := rm.ctx.Deadline(); !ok {
+		t.Fatalf("receipt ctx has no timeout")
+	}
+}
+
+type failingSeenStore struct{}
+
+func (failingSeenStore) MarkSeen(context.Context, string, time.Duration) (bool, error) {
+	return false, errors.New("store down")
+}
+
+func (failingSeenStore) Forget(context.Context, string) error { return errors.New("store down") }
+
+func TestWebhookDispatcher_Dedup(t *testing.T) {
+	event := domain.WebhookEvent{
+		Entry: []domain.WebhookEntry{{
+			Changes: []domain.WebhookChange{
+				{Value: domain.WebhookValue{Messages: []domain.InboundMessage{{ID: "m1"}}}},
+				{Value: domain.WebhookValue{Statuses: []domain.MessageStatus{{ID: "w1", Status: "sent"}}}},
+			},
+		}},
+	}
+	next := domain.WebhookEvent{
+		Entry: []domain.WebhookEntry{{
+			Changes: []domain.WebhookChange{
+				{Value: domain.WebhookValue{Statuses: []domain.MessageStatus{{ID: "w1", Status: "delivered"}}}},
+			},
+		}},
+	}
+
+	h := &fakeWebhookHandler{}
+	d := services.NewWebhookDispatcher(h).WithDedup(seen.NewLRU(0), 0)
+	d.Dispatch(event, http.Header{})
+	d.Dispatch(event, http.Header{}) // redelivery
+	d.Dispatch(next, http.Header{})
+
+	if len(h.messages) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(h.messages))
+	}
+	if len(h.statuses) != 2 || h.statuses[1].Status != "delivered" {
+		t.Fatalf("expected sent then delivered, got %+v", h.statuses)
+	}
+	if d.Duplicates() != 2 {
+		t.Fatalf("duplicates = %d, want 2", d.Duplicates())
+	}
+
+	// A failing store must not drop events.
+	h = &fakeWebhookHandler{}
+	d = services.NewWebhookDispatcher(h).WithDedup(failingSeenStore{}, 0)
+	d.Dispatch(event, http.Header{})
+	d.Dispatch(event, http.Header{})
+	if len(h.messages) != 2 || d.Duplicates() != 0 {
+		t.Fatalf("messages=%d duplicates=%d", len(h.messages), d.Duplicates())
+	}
+}
+
+// flakyHandler fails OnMessage for IDs in fail and records the rest.
+type flakyHandler struct {
+	fail map[string]bool
+	ok   []string
+	ctx  context.Context
+}
+
+func (f *flakyHandler) Always(ctx context.Context, _ domain.WebhookEvent, _ http.Header) error {
+	f.ctx = ctx
+	return nil
+}
+
+func (f *flakyHandler) OnMessage(_ context.Context, m domain.InboundMessage, _ domain.WebhookEvent, _ http.Header) error {
+	if f.fail[m.ID] {
+		return errors.New("try later")
+	}
+	f.ok = append(f.ok, m.ID)
+	return nil
+}
+
+func (f *flakyHandler) OnStatus(context.Context, domain.MessageStatus, domain.WebhookEvent, http.Header) error {
+	return nil
+}
+
+func TestWebhookDispatcher_DispatchContextErrors(t *testing.T) {
+	event := domain.WebhookEvent{
+		Entry: []domain.WebhookEntry{{
+			Changes: []domain.WebhookChange{
+				{Value: domain.WebhookValue{Messages: []domain.InboundMessage{{ID: "m1"}, {ID: "m2"}, {ID: "m3"}}}},
+			},
+		}},
+	}
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "req")
+
+	h := &flakyHandler{fail: map[string]bool{"m2": true}}
+	d := services.NewWebhookDispatcherV2(h).WithDedup(seen.NewLRU(0), 0)
+
+	err := d.DispatchContext(ctx, event, http.Header{})
+	if err == nil || !strings.Contains(err.Error(), "message m2: try later") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if h.ctx.Value(ctxKey{}) != "req" {
+		t.Fatal("handler did not receive the dispatch context")
+	}
+	if len(h.ok) != 2 || h.ok[0] != "m1" || h.ok[1] != "m3" {
+		t.Fatalf("a failing message must not stop the others: %v", h.ok)
+	}
+
+	// The redelivery only runs the message that failed.
+	h.fail = nil
+	if err := d.DispatchContext(ctx, event, http.Header{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(h.ok) != 3 || h.ok[2] != "m2" {
+		t.Fatalf("redelivery dispatched %v", h.ok)
+	}
+	if d.Duplicates() != 2 {
+		t.Fatalf("duplicates = %d, want 2", d.Duplicates())
+	}
+}
+
+func TestAdaptWebhookHandler(t *testing.T) {
+	h := &fakeWebhookHandler{}
+	v2 := services.AdaptWebhookHandler(h)
+	if err := v2.OnMessage(context.Background(), domain.InboundMessage{ID: "m1"}, domain.WebhookEvent{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := v2.OnStatus(context.Background(), domain.MessageStatus{ID: "s1"}, domain.WebhookEvent{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(h.messages) != 1 || len(h.statuses) != 1 {
+		t.Fatalf("legacy handler not called: %+v", h)
+	}
+}
+
+func TestWebhookDispatcher_FieldHandlers(t *testing.T) {
+	event, err := domain.ParseWebhookEvent([]byte(`{"entry":[{"changes":[
+		{"field":"message_template_status_update","value":{"event":"APPROVED","message_template_id":7,"message_template_name":"welcome"}},
+		{"field":"security","value":{"display_phone_number":"15550783881","event":"PIN_CHANGED"}},
+		{"field":"account_review_update","value":{"decision":"APPROVED"}},
+		{"field":"messages","value":{"messages":[{"id":"m1"}]}}
+	]}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	h := &fakeWebhookHandler{}
+	d := services.NewWebhookDispatcher(h).WithFieldHandlers(services.WebhookFieldHandlers{
+		TemplateStatus: func(_ context.Context, u domain.TemplateStatusUpdate, _ domain.WebhookEvent, _ http.Header) error {
+			got = append(got, u.MessageTemplateName+":"+u.Event)
+			return nil
+		},
+		Security: func(_ context.Context, u domain.SecurityUpdate, _ domain.WebhookEvent, _ http.Header) error {
+			return errors.New("alert failed")
+		},
+	})
+
+	err = d.DispatchContext(context.Background(), event, http.Header{})
+	if err == nil || !strings.Contains(err.Error(), "field security: alert failed") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0] != "welcome:APPROVED" {
+		t.Fatalf("template callback: %v", got)
+	}
+	if len(h.messages) != 1 {
+		t.Fatalf("messages still dispatched: %+v", h.messages)
+	}
+}
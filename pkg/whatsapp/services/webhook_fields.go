@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
+)
+
+// WebhookFieldFunc handles the typed value of a non-message webhook field. A
+// returned error has the same effect as in WebhookHandlerV2.
+type WebhookFieldFunc[T any] func(ctx context.Context, u T, e domain.WebhookEvent, h http.Header) error
+
+// WebhookFieldHandlers holds one callback per non-message webhook field (see
+// domain.WebhookField*); nil callbacks are skipped.
+type WebhookFieldHandlers struct {
+	TemplateStatus     WebhookFieldFunc[domain.TemplateStatusUpdate]
+	TemplateQuality    WebhookFieldFunc[domain.TemplateQualityUpdate]
+	TemplateCategory   WebhookFieldFunc[domain.TemplateCategoryUpdate]
+	PhoneNumberQuality WebhookFieldFunc[domain.PhoneNumberQualityUpdate]
+	PhoneNumberName    WebhookFieldFunc[domain.PhoneNumberNameUpdate]
+	Account            WebhookFieldFunc[domain.AccountUpdate]
+	AccountReview      WebhookFieldFunc[domain.AccountReviewUpdate]
+	BusinessCapability WebhookFieldFunc[domain.BusinessCapabilityUpdate]
+	Security           WebhookFieldFunc[domain.SecurityUpdate]
+	UserPreferences    WebhookFieldFunc[domain.UserPreferencesUpdate]
+}
+
+func (f *WebhookFieldHandlers) dispatch(ctx context.Context, ch domain.WebhookChange, e domain.WebhookEvent, h http.Header) error {
+	switch u := ch.Update.(type) {
+	case *domain.TemplateStatusUpdate:
+		return callField(ctx, f.TemplateStatus, u, e, h)
+	case *domain.TemplateQualityUpdate:
+		return callField(ctx, f.TemplateQuality, u, e, h)
+	case *domain.TemplateCategoryUpdate:
+		return callField(ctx, f.TemplateCategory, u, e, h)
+	case *domain.PhoneNumberQualityUpdate:
+		return callField(ctx, f.PhoneNumberQuality, u, e, h)
+	case *domain.PhoneNumberNameUpdate:
+		return callField(ctx, f.PhoneNumberName, u, e, h)
+	case *domain.AccountUpdate:
+		return callField(ctx, f.Account, u, e, h)
+	case *domain.AccountReviewUpdate:
+		return callField(ctx, f.AccountReview, u, e, h)
+	case *domain.BusinessCapabilityUpdate:
+		return callField(ctx, f.BusinessCapability, u, e, h)
+	case *domain.SecurityUpdate:
+		return callField(ctx, f.Security, u, e, h)
+	case *domain.UserPreferencesUpdate:
+		return callField(ctx, f.UserPreferences, u, e, h)
+	}
+	return nil
+}
+
+func callField[T any](ctx context.Context, fn WebhookFieldFunc[T], u *T, e domain.WebhookEvent, h http.Header) error {
+	if fn == nil || u == nil {
+		return nil
+	}
+	return fn(ctx, *u, e, h)
+}
@@ -0,0 +1,311 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"runtime/debug"
+	"slices"
+	"time"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
+)
+
+// WebhookRequest is what a WebhookRouter route receives. Message or Status is
+// set for message and status routes; Change is always set, to the change the
+// item came in (its Metadata tells which business number it is for).
+type WebhookRequest struct {
+	Event  domain.WebhookEvent
+	Header http.Header
+
+	Message *domain.InboundMessage // message routes
+	Status  *domain.MessageStatus  // status routes
+	Change  *domain.WebhookChange
+}
+
+// Kind returns "message", "status" or "field".
+func (r *WebhookRequest) Kind() string {
+	switch {
+	case r.Message != nil:
+		return "message"
+	case r.Status != nil:
+		return "status"
+	}
+	return "field"
+}
+
+// ID returns the message or status ID, or the field name for field routes.
+func (r *WebhookRequest) ID() string {
+	switch {
+	case r.Message != nil:
+		return r.Message.ID
+	case r.Status != nil:
+		return r.Status.ID
+	case r.Change != nil:
+		return r.Change.Field
+	}
+	return ""
+}
+
+// WebhookRouteFunc handles one routed item. A returned error has the same
+// effect as in WebhookHandlerV2.
+type WebhookRouteFunc func(ctx context.Context, r *WebhookRequest) error
+
+// WebhookMiddleware wraps every route of a WebhookRouter, including the fallback.
+type WebhookMiddleware func(next WebhookRouteFunc) WebhookRouteFunc
+
+// WebhookRouter is a WebhookHandlerV2 (see Handler and Dispatcher) that sends each message, status and
+// change to the route registered for its type instead of one big switch.
+// Routes are matched from the most to the least specific:
+//
+//   - messages: interactive button/list reply by ID, then by reply type with
+//     ID "", then the message type (OnText, OnImage, ...);
+//   - statuses: by status value, then OnStatus("");
+//   - changes of fields other than "messages": by field.
+//
+// Anything unmatched goes to Fallback, or is ignored when there is none.
+// Register routes before the first dispatch; the router is not safe for
+// concurrent registration.
+type WebhookRouter struct {
+	types    map[string]WebhookRouteFunc
+	buttons  map[string]WebhookRouteFunc
+	lists    map[string]WebhookRouteFunc
+	statuses map[string]WebhookRouteFunc
+	fields   map[string]WebhookRouteFunc
+	fallback WebhookRouteFunc
+	mw       []WebhookMiddleware
+}
+
+func NewWebhookRouter() *WebhookRouter {
+	return &WebhookRouter{
+		types:    map[string]WebhookRouteFunc{},
+		buttons:  map[string]WebhookRouteFunc{},
+		lists:    map[string]WebhookRouteFunc{},
+		statuses: map[string]WebhookRouteFunc{},
+		fields:   map[string]WebhookRouteFunc{},
+	}
+}
+
+// Dispatcher returns a WebhookDispatcher for the router, ready for
+// webhook.NewHandler and the dispatcher options (WithDedup, ...).
+func (r *WebhookRouter) Dispatcher() *WebhookDispatcher { return NewWebhookDispatcherV2(r.Handler()) }
+
+// Handler returns the router as a WebhookHandlerV2.
+func (r *WebhookRouter) Handler() WebhookHandlerV2 { return routerHandler{r} }
+
+// Use appends middleware; the first one added is the outermost.
+func (r *WebhookRouter) Use(mw ...WebhookMiddleware) *WebhookRouter {
+	r.mw = append(r.mw, mw...)
+	return r
+}
+
+// Fallback handles every message, status and change without a route.
+func (r *WebhookRouter) Fallback(fn WebhookRouteFunc) *WebhookRouter {
+	r.fallback = fn
+	return r
+}
+
+// OnMessageType routes messages whose Type is typ (see domain.MessageType*).
+func (r *WebhookRouter) OnMessageType(typ string, fn WebhookRouteFunc) *WebhookRouter {
+	r.types[typ] = fn
+	return r
+}
+
+func (r *WebhookRouter) OnText(fn WebhookRouteFunc) *WebhookRouter {
+	return r.OnMessageType(domain.MessageTypeText, fn)
+}
+
+func (r *WebhookRouter) OnImage(fn WebhookRouteFunc) *WebhookRouter {
+	return r.OnMessageType(domain.MessageTypeImage, fn)
+}
+
+func (r *WebhookRouter) OnReaction(fn WebhookRouteFunc) *WebhookRouter {
+	return r.OnMessageType(domain.MessageTypeReaction, fn)
+}
+
+func (r *WebhookRouter) OnLocation(fn WebhookRouteFunc) *WebhookRouter {
+	return r.OnMessageType(domain.MessageTypeLocation, fn)
+}
+
+// OnInteractiveButton routes taps on the reply button with the given ID; ""
+// matches any button without a route of its own.
+func (r *WebhookRouter) OnInteractiveButton(id string, fn WebhookRouteFunc) *WebhookRouter {
+	r.buttons[id] = fn
+	return r
+}
+
+// OnListReply routes selections of the list row with the given ID; ""
+// matches any row without a route of its own.
+func (r *WebhookRouter) OnListReply(id string, fn WebhookRouteFunc) *WebhookRouter {
+	r.lists[id] = fn
+	return r
+}
+
+// OnStatus routes statuses with the given value (see domain.MessageStatus*);
+// "" matches any status without a route of its own.
+func (r *WebhookRouter) OnStatus(status string, fn WebhookRouteFunc) *WebhookRouter {
+	r.statuses[status] = fn
+	return r
+}
+
+// OnField routes changes of the given webhook field, e.g.
+// "message_template_status_update"; r.Change.Update holds the typed value,
+// or is nil with r.Change.Raw set when the value did not match its type
+// (see domain.WebhookChange). Changes of the "messages" field are
+// routed per message and status instead, unless OnField("messages") is set,
+// in which case the route is called once per change as well.
+func (r *WebhookRouter) OnField(field string, fn WebhookRouteFunc) *WebhookRouter {
+	r.fields[field] = fn
+	return r
+}
+
+// routerHandler adapts WebhookRouter to WebhookHandlerV2; the router's own
+// OnStatus registers routes.
+type routerHandler struct{ r *WebhookRouter }
+
+// Always routes the event's changes by field.
+func (rh routerHandler) Always(ctx context.Context, e domain.WebhookEvent, h http.Header) error {
+	r := rh.r
+	var errs []error
+	for _, entry := range e.Entry {
+		for i := range entry.Changes {
+			ch := &entry.Changes[i]
+			fn, ok := r.fields[ch.Field]
+			if !ok && (ch.Field == "messages" || ch.Field == "") {
+				continue // routed per message and status
+			}
+			if err := r.serve(ctx, fn, &WebhookRequest{Event: e, Header: h, Change: ch}); err != nil {
+				errs = append(errs, fmt.Errorf("field %s: %w", ch.Field, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (rh routerHandler) OnMessage(ctx context.Context, m domain.InboundMessage, e domain.WebhookEvent, h http.Header) error {
+	r := rh.r
+	ch := originChange(e, func(v domain.WebhookValue) bool {
+		return slices.ContainsFunc(v.Messages, func(x domain.InboundMessage) bool { return x.ID == m.ID })
+	})
+	return r.serve(ctx, r.messageRoute(m), &WebhookRequest{Event: e, Header: h, Message: &m, Change: ch})
+}
+
+func (rh routerHandler) OnStatus(ctx context.Context, s domain.MessageStatus, e domain.WebhookEvent, h http.Header) error {
+	r := rh.r
+	fn, ok := r.statuses[s.Status]
+	if !ok {
+		fn = r.statuses[""]
+	}
+	ch := originChange(e, func(v domain.WebhookValue) bool {
+		return slices.ContainsFunc(v.Statuses, func(x domain.MessageStatus) bool { return x.ID == s.ID && x.Status == s.Status })
+	})
+	return r.serve(ctx, fn, &WebhookRequest{Event: e, Header: h, Status: &s, Change: ch})
+}
+
+// originChange returns the first change of e whose value matches, or nil.
+func originChange(e domain.WebhookEvent, match func(domain.WebhookValue) bool) *domain.WebhookChange {
+	for _, entry := range e.Entry {
+		for i := range entry.Changes {
+			if match(entry.Changes[i].Value) {
+				return &entry.Changes[i]
+			}
+		}
+	}
+	return nil
+}
+
+func (r *WebhookRouter) messageRoute(m domain.InboundMessage) WebhookRouteFunc {
+	if in := m.Interactive; in != nil {
+		var byID map[string]WebhookRouteFunc
+		switch {
+		case in.ButtonReply != nil:
+			byID = r.buttons
+		case in.ListReply != nil:
+			byID = r.lists
+		}
+		if fn, ok := byID[in.ReplyID()]; ok {
+			return fn
+		}
+		if fn, ok := byID[""]; ok {
+			return fn
+		}
+	}
+	return r.types[m.Type]
+}
+
+// serve runs fn, or the fallback when fn is nil, through the middleware.
+func (r *WebhookRouter) serve(ctx context.Context, fn WebhookRouteFunc, req *WebhookRequest) error {
+	if fn == nil {
+		fn = r.fallback
+	}
+	if fn == nil {
+		return nil
+	}
+	for _, mw := range slices.Backward(r.mw) {
+		fn = mw(fn)
+	}
+	return fn(ctx, req)
+}
+
+// WebhookLogging logs every routed item with its outcome and duration.
+func WebhookLogging() WebhookMiddleware {
+	return func(next WebhookRouteFunc) WebhookRouteFunc {
+		return func(ctx context.Context, r *WebhookRequest) error {
+			start := time.Now()
+			err := next(ctx, r)
+			if err != nil {
+				log.Printf("webhook %s %s: %v (%s)", r.Kind(), r.ID(), err, time.Since(start))
+			} else {
+				log.Printf("webhook %s %s: ok (%s)", r.Kind(), r.ID(), time.Since(start))
+			}
+			return err
+		}
+	}
+}
+
+// WebhookRecovery turns a panicking route into an error, logging the stack.
+// Like any route error it makes Meta redeliver the event.
+func WebhookRecovery() WebhookMiddleware {
+	return func(next WebhookRouteFunc) WebhookRouteFunc {
+		return func(ctx context.Context, r *WebhookRequest) (err error) {
+			defer func() {
+				if v := recover(); v != nil {
+					log.Printf("webhook %s %s: panic: %v\n%s", r.Kind(), r.ID(), v, debug.Stack())
+					err = fmt.Errorf("panic: %v", v)
+				}
+			}()
+			return next(ctx, r)
+		}
+	}
+}
+
+// WebhookAuth skips items for which allow returns false. Skipped items are
+// logged and acknowledged, not failed: redelivering them would not help.
+func WebhookAuth(allow func(ctx context.Context, r *WebhookRequest) bool) WebhookMiddleware {
+	return func(next WebhookRouteFunc) WebhookRouteFunc {
+		return func(ctx context.Context, r *WebhookRequest) error {
+			if !allow(ctx, r) {
+				log.Printf("webhook %s %s: not allowed", r.Kind(), r.ID())
+				return nil
+			}
+			return next(ctx, r)
+		}
+	}
+}
+
+// AllowPhoneNumberIDs is a WebhookAuth check rejecting items addressed to
+// business phone numbers other than ids, judged by the change each item came
+// in (see domain.WebhookChange.Metadata, which also reads typed fields).
+// Changes without phone metadata, such as account-level fields, are accepted;
+// requests without a change are not.
+func AllowPhoneNumberIDs(ids ...string) func(ctx context.Context, r *WebhookRequest) bool {
+	return func(_ context.Context, r *WebhookRequest) bool {
+		if r.Change == nil {
+			return false
+		}
+		md := r.Change.Metadata()
+		return md == nil || slices.Contains(ids, md.PhoneNumberID)
+	}
+}
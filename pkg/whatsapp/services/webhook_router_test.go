@@ -0,0 +1,197 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/services"
+)
+
+// record returns a route that appends name to *got.
+func record(got *[]string, name string) services.WebhookRouteFunc {
+	return func(_ context.Context, r *services.WebhookRequest) error {
+		*got = append(*got, name+":"+r.ID())
+		return nil
+	}
+}
+
+func routedEvent() domain.WebhookEvent {
+	meta := &domain.WebhookMetadata{PhoneNumberID: "111"}
+	return domain.WebhookEvent{Entry: []domain.WebhookEntry{{
+		Changes: []domain.WebhookChange{
+			{Field: "messages", Value: domain.WebhookValue{Metadata: meta, Messages: []domain.InboundMessage{
+				{ID: "t1", Type: domain.MessageTypeText, Text: &domain.MessageText{Body: "hi"}},
+				{ID: "i1", Type: domain.MessageTypeImage},
+				{ID: "b1", Type: domain.MessageTypeInteractive, Interactive: &domain.InteractiveObject{
+					Type: domain.InteractiveReplyTypeButton, ButtonReply: &domain.InteractiveButtonReply{ID: "yes"}}},
+				{ID: "b2", Type: domain.MessageTypeInteractive, Interactive: &domain.InteractiveObject{
+					Type: domain.InteractiveReplyTypeButton, ButtonReply: &domain.InteractiveButtonReply{ID: "other"}}},
+				{ID: "l1", Type: domain.MessageTypeInteractive, Interactive: &domain.InteractiveObject{
+					Type: domain.InteractiveReplyTypeList, ListReply: &domain.InteractiveListReply{ID: "row"}}},
+				{ID: "r1", Type: domain.MessageTypeReaction},
+				{ID: "loc1", Type: domain.MessageTypeLocation},
+				{ID: "a1", Type: domain.MessageTypeAudio},
+			}}},
+			{Field: "messages", Value: domain.WebhookValue{Metadata: meta, Statuses: []domain.MessageStatus{
+				{ID: "w1", Status: domain.MessageStatusRead},
+				{ID: "w2", Status: domain.MessageStatusDelivered},
+			}}},
+			{Field: "message_template_status_update"},
+			{Field: "account_update"},
+		},
+	}}}
+}
+
+func TestWebhookRouter_Routes(t *testing.T) {
+	var got []string
+	r := services.NewWebhookRouter().
+		OnText(record(&got, "text")).
+		OnImage(record(&got, "image")).
+		OnInteractiveButton("yes", record(&got, "yes")).
+		OnInteractiveButton("", record(&got, "button")).
+		OnListReply("", record(&got, "list")).
+		OnReaction(record(&got, "reaction")).
+		OnLocation(record(&got, "location")).
+		OnStatus(domain.MessageStatusRead, record(&got, "read")).
+		OnStatus("", record(&got, "status")).
+		OnField("message_template_status_update", record(&got, "field")).
+		Fallback(record(&got, "fallback"))
+
+	if err := r.Dispatcher().DispatchContext(context.Background(), routedEvent(), http.Header{}); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"field:message_template_status_update", "fallback:account_update",
+		"text:t1", "image:i1", "yes:b1", "button:b2", "list:l1", "reaction:r1", "location:loc1", "fallback:a1",
+		"read:w1", "status:w2",
+	}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Fatalf("routed\n %v\nwant\n %v", got, want)
+	}
+}
+
+func TestWebhookRouter_NoRouteNoFallback(t *testing.T) {
+	var got []string
+	r := services.NewWebhookRouter().OnText(record(&got, "text"))
+	if err := r.Dispatcher().DispatchContext(context.Background(), routedEvent(), nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0] != "text:t1" {
+		t.Fatalf("routed %v", got)
+	}
+}
+
+func TestWebhookRouter_Middleware(t *testing.T) {
+	var order []string
+	tag := func(name string) services.WebhookMiddleware {
+		return func(next services.WebhookRouteFunc) services.WebhookRouteFunc {
+			return func(ctx context.Context, r *services.WebhookRequest) error {
+				order = append(order, name)
+				return next(ctx, r)
+			}
+		}
+	}
+	r := services.NewWebhookRouter().
+		Use(tag("outer"), tag("inner"), services.WebhookLogging(), services.WebhookRecovery()).
+		OnText(func(context.Context, *services.WebhookRequest) error { panic("bad text") }).
+		OnImage(func(context.Context, *services.WebhookRequest) error { return errors.New("try later") })
+
+	err := r.Dispatcher().DispatchContext(context.Background(), routedEvent(), nil)
+	if err == nil || !strings.Contains(err.Error(), "message t1: panic: bad text") || !strings.Contains(err.Error(), "message i1: try later") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(order) != 4 || order[0] != "outer" || order[1] != "inner" {
+		t.Fatalf("middleware order %v", order)
+	}
+}
+
+func TestWebhookRouter_Auth(t *testing.T) {
+	var got []string
+	r := services.NewWebhookRouter().
+		Use(services.WebhookAuth(services.AllowPhoneNumberIDs("222"))).
+		OnText(record(&got, "text")).
+		OnField("account_update", record(&got, "field"))
+
+	if err := r.Dispatcher().DispatchContext(context.Background(), routedEvent(), nil); err != nil {
+		t.Fatal(err)
+	}
+	// Messages for phone 111 are dropped; the account-level change has no phone metadata.
+	if len(got) != 1 || got[0] != "field:account_update" {
+		t.Fatalf("routed %v", got)
+	}
+
+	got = nil
+	r = services.NewWebhookRouter().
+		Use(services.WebhookAuth(services.AllowPhoneNumberIDs("111"))).
+		OnText(record(&got, "text"))
+	if err := r.Dispatcher().DispatchContext(context.Background(), routedEvent(), nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("routed %v", got)
+	}
+
+	// One event for two numbers: each item is judged by its own change.
+	mixed := domain.WebhookEvent{Entry: []domain.WebhookEntry{{Changes: []domain.WebhookChange{
+		{Field: "messages", Value: domain.WebhookValue{
+			Metadata: &domain.WebhookMetadata{PhoneNumberID: "111"},
+			Messages: []domain.InboundMessage{{ID: "a1", Type: domain.MessageTypeText}},
+			Statuses: []domain.MessageStatus{{ID: "wa1", Status: domain.MessageStatusRead}},
+		}},
+		{Field: "messages", Value: domain.WebhookValue{
+			Metadata: &domain.WebhookMetadata{PhoneNumberID: "222"},
+			Messages: []domain.InboundMessage{{ID: "b1", Type: domain.MessageTypeText}},
+			Statuses: []domain.MessageStatus{{ID: "wb1", Status: domain.MessageStatusRead}},
+		}},
+	}}}}
+	for _, tc := range []struct {
+		allow string
+		want  string
+	}{
+		{"111", "text:a1 status:wa1"},
+		{"222", "text:b1 status:wb1"},
+	} {
+		got = nil
+		r = services.NewWebhookRouter().
+			Use(services.WebhookAuth(services.AllowPhoneNumberIDs(tc.allow))).
+			OnText(record(&got, "text")).
+			OnStatus("", record(&got, "status"))
+		if err := r.Dispatcher().DispatchContext(context.Background(), mixed, nil); err != nil {
+			t.Fatal(err)
+		}
+		if strings.Join(got, " ") != tc.want {
+			t.Fatalf("allow %s: routed %v, want %s", tc.allow, got, tc.want)
+		}
+	}
+}
+
+func TestWebhookRouter_AuthTypedField(t *testing.T) {
+	e, err := domain.ParseWebhookEvent([]byte(`{"entry":[{"changes":[{"field":"user_preferences","value":{` +
+		`"messaging_product":"whatsapp","metadata":{"phone_number_id":"999"},` +
+		`"user_preferences":[{"wa_id":"5511999999999","category":"marketing_messages","value":"stop"}]}}]}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		allow string
+		want  int
+	}{
+		{"111", 0}, // another business number
+		{"999", 1},
+	} {
+		var got []string
+		r := services.NewWebhookRouter().
+			Use(services.WebhookAuth(services.AllowPhoneNumberIDs(tc.allow))).
+			OnField(domain.WebhookFieldUserPreferences, record(&got, "prefs"))
+		if err := r.Dispatcher().DispatchContext(context.Background(), e, nil); err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != tc.want {
+			t.Fatalf("allow %s: routed %v", tc.allow, got)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package filestore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/ports"
+)
+
+// ContentAddressed stores every distinct content once under its SHA-256 and
+// keeps names as small references to it, so saving the same media under many
+// names (or downloading it again) costs no extra space. Layout:
+//
+//	root/objects/ab/abcdef...  content, named by its hex SHA-256
+//	root/names/<name>          hex SHA-256 of the content saved as <name>
+//
+// Callers holding the sha256 of a media (domain.DownloadLinkURL.Sha256) can
+// check Has and Link before downloading it at all.
+type ContentAddressed struct {
+	staged
+	root string
+}
+
+var _ ports.FileManagerAPI = (*ContentAddressed)(nil)
+
+// NewContentAddressed returns a store rooted at root, creating it when missing.
+func NewContentAddressed(root string) (*ContentAddressed, error) {
+	for _, d := range []string{"objects", "names"} {
+		if err := os.MkdirAll(filepath.Join(root, d), 0o755); err != nil {
+			return nil, fmt.Errorf("filestore: create dir: %w", err)
+		}
+	}
+	return &ContentAddressed{root: root}, nil
+}
+
+// Save stores the staged content, unless an identical one already exists, and
+// points fileName at it.
+func (c *ContentAddressed) Save(ctx context.Context, fileName string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	name, err := SanitizeName(fileName)
+	if err != nil {
+		return err
+	}
+	data, err := c.take()
+	if err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256(data)
+	key := hex.EncodeToString(sum[:])
+	obj := c.objectPath(key)
+	if _, err := os.Stat(obj); isNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(obj), 0o755); err != nil {
+			return fmt.Errorf("filestore: create dir: %w", err)
+		}
+		if err := writeFileAtomic(obj, data); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return fmt.Errorf("filestore: stat: %w", err)
+	}
+	return writeFileAtomic(filepath.Join(c.root, "names", name), []byte(key))
+}
+
+// Open returns the content saved as fileName. A hex SHA-256 is accepted too.
+func (c *ContentAddressed) Open(ctx context.Context, fileName string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if key, ok := normalizeSum(fileName); ok && c.Has(key) {
+		return os.ReadFile(c.objectPath(key))
+	}
+	name, err := SanitizeName(fileName)
+	if err != nil {
+		return nil, err
+	}
+	ref, err := os.ReadFile(filepath.Join(c.root, "names", name))
+	if err != nil {
+		return nil, err
+	}
+	key, ok := normalizeSum(string(ref))
+	if !ok {
+		return nil, fmt.Errorf("filestore: corrupt reference %s", name)
+	}
+	return os.ReadFile(c.objectPath(key))
+}
+
+// Has reports whether content with the given hex SHA-256 is stored.
+func (c *ContentAddressed) Has(sum string) bool {
+	key, ok := normalizeSum(sum)
+	if !ok {
+		return false
+	}
+	_, err := os.Stat(c.objectPath(key))
+	return err == nil
+}
+
+// Link points fileName at already stored content, skipping a download.
+func (c *ContentAddressed) Link(ctx context.Context, sum, fileName string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	key, ok := normalizeSum(sum)
+	if !ok || !c.Has(key) {
+		return fmt.Errorf("link %s: %w", sum, fs.ErrNotExist)
+	}
+	name, err := SanitizeName(fileName)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(c.root, "names", name), []byte(key))
+}
+
+func (c *ContentAddressed) objectPath(key string) string {
+	return filepath.Join(c.root, "objects", key[:2], key)
+}
+
+// normalizeSum lower-cases s and reports whether it is a hex SHA-256.
+func normalizeSum(s string) (string, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if len(s) != hex.EncodedLen(sha256.Size) {
+		return "", false
+	}
+	if _, err := hex.DecodeString(s); err != nil {
+		return "", false
+	}
+	return s, true
+}
@@ -0,0 +1,134 @@
+package filestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/ports"
+)
+
+// maxNameBytes is the usual file name limit of Linux, macOS and Windows file systems.
+const maxNameBytes = 255
+
+// Dir is a FileManagerAPI writing into a single directory. Names are reduced
+// to a safe base name (see SanitizeName), so "a/b.pdf" and "b.pdf" refer to
+// the same file and nothing is ever written outside the directory. Writes go
+// to a temporary file that is renamed into place, so readers never observe a
+// partially written file.
+type Dir struct {
+	staged
+	root string
+}
+
+var _ ports.FileManagerAPI = (*Dir)(nil)
+
+// NewDir returns a Dir rooted at root, creating it when missing.
+func NewDir(root string) (*Dir, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("filestore: create dir: %w", err)
+	}
+	return &Dir{root: root}, nil
+}
+
+// Path returns where fileName is stored.
+func (d *Dir) Path(fileName string) (string, error) {
+	name, err := SanitizeName(fileName)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(d.root, name), nil
+}
+
+func (d *Dir) Save(ctx context.Context, fileName string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	p, err := d.Path(fileName)
+	if err != nil {
+		return err
+	}
+	data, err := d.take()
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(p, data)
+}
+
+func (d *Dir) Open(ctx context.Context, fileName string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	p, err := d.Path(fileName)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(p)
+}
+
+// SanitizeName reduces name to a base name safe on common file systems: path
+// components are dropped, control and reserved characters become "_", leading
+// dots and surrounding spaces are trimmed, and the result is capped at 255 bytes.
+func SanitizeName(name string) (string, error) {
+	name = path.Base(strings.ReplaceAll(name, `\`, "/"))
+	if name == "/" || name == "." {
+		return "", ErrInvalidName
+	}
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f || strings.ContainsRune(`<>:"/\|?*`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+	name = strings.TrimLeft(strings.TrimSpace(name), ".")
+	name = strings.TrimRight(name, ". ")
+	for len(name) > maxNameBytes {
+		// Trim whole runes from the end.
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	if name == "" {
+		return "", ErrInvalidName
+	}
+	return name, nil
+}
+
+// writeFileAtomic writes data to a temporary file next to p and renames it over p.
+func writeFileAtomic(p string, data []byte) (err error) {
+	tmp, err := os.CreateTemp(filepath.Dir(p), "."+filepath.Base(p)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("filestore: create temp: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err = tmp.Write(data); err != nil {
+		return fmt.Errorf("filestore: write: %w", err)
+	}
+	if err = tmp.Sync(); err != nil {
+		return fmt.Errorf("filestore: sync: %w", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("filestore: close: %w", err)
+	}
+	if err = os.Chmod(tmp.Name(), 0o644); err != nil {
+		return fmt.Errorf("filestore: chmod: %w", err)
+	}
+	if err = os.Rename(tmp.Name(), p); err != nil {
+		return fmt.Errorf("filestore: rename: %w", err)
+	}
+	return nil
+}
+
+// isNotExist reports whether err means the file is missing.
+func isNotExist(err error) bool { return errors.Is(err, fs.ErrNotExist) }
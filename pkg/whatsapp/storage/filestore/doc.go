@@ -0,0 +1,13 @@
+// Package filestore provides ready-made ports.FileManagerAPI implementations:
+//
+//   - Memory keeps files in a map, for tests and short-lived processes.
+//   - Dir writes files into a directory with sanitised names and atomic renames.
+//   - ContentAddressed stores each distinct content once, keyed by its SHA-256,
+//     so repeated downloads of the same media are deduplicated.
+//
+// A FileManagerAPI is used in two steps: SetData stages the downloaded bytes and
+// Save persists the staged bytes under a name. Open returns the saved bytes, or
+// an error matching fs.ErrNotExist. Implementations are safe for concurrent use,
+// but the staged data is shared, so use one instance per download when saving
+// concurrently. Custom implementations can be checked with filestoretest.Run.
+package filestore
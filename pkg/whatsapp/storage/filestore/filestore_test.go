@@ -0,0 +1,136 @@
+package filestore_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/ports"
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/storage/filestore"
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/storage/filestore/filestoretest"
+)
+
+func TestMemoryConformance(t *testing.T) {
+	filestoretest.Run(t, func(t *testing.T) ports.FileManagerAPI { return filestore.NewMemory() })
+}
+
+func TestDirConformance(t *testing.T) {
+	filestoretest.Run(t, func(t *testing.T) ports.FileManagerAPI {
+		d, err := filestore.NewDir(t.TempDir())
+		if err != nil {
+			t.Fatalf("NewDir: %v", err)
+		}
+		return d
+	})
+}
+
+func TestContentAddressedConformance(t *testing.T) {
+	filestoretest.Run(t, func(t *testing.T) ports.FileManagerAPI {
+		c, err := filestore.NewContentAddressed(t.TempDir())
+		if err != nil {
+			t.Fatalf("NewContentAddressed: %v", err)
+		}
+		return c
+	})
+}
+
+func TestSanitizeName(t *testing.T) {
+	cases := map[string]string{
+		"photo.jpg":              "photo.jpg",
+		"../../etc/passwd":       "passwd",
+		`..\..\boot.ini`:         "boot.ini",
+		"dir/sub/file.pdf":       "file.pdf",
+		"..hidden":               "hidden",
+		"a:b*c?.txt":             "a_b_c_.txt",
+		"tab\tname.txt":          "tab_name.txt",
+		"trailing. ":             "trailing",
+		strings.Repeat("é", 200): strings.Repeat("é", 127),
+	}
+	for in, want := range cases {
+		got, err := filestore.SanitizeName(in)
+		if err != nil || got != want {
+			t.Fatalf("SanitizeName(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", "..", "/", " . "} {
+		if _, err := filestore.SanitizeName(bad); !errors.Is(err, filestore.ErrInvalidName) {
+			t.Fatalf("SanitizeName(%q) should fail, got %v", bad, err)
+		}
+	}
+}
+
+func TestDirStaysInsideRoot(t *testing.T) {
+	parent := t.TempDir()
+	root := filepath.Join(parent, "media")
+	d, err := filestore.NewDir(root)
+	if err != nil {
+		t.Fatalf("NewDir: %v", err)
+	}
+	ctx := context.Background()
+	_ = d.SetData(ctx, []byte("x"))
+	if err := d.Save(ctx, "../escape.txt"); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(parent, "escape.txt")); !os.IsNotExist(err) {
+		t.Fatalf("file written outside root")
+	}
+	entries, _ := os.ReadDir(root)
+	if len(entries) != 1 || entries[0].Name() != "escape.txt" {
+		t.Fatalf("unexpected dir content (temp files left?): %v", entries)
+	}
+}
+
+func TestContentAddressedDedupe(t *testing.T) {
+	root := t.TempDir()
+	c, err := filestore.NewContentAddressed(root)
+	if err != nil {
+		t.Fatalf("NewContentAddressed: %v", err)
+	}
+	ctx := context.Background()
+	_ = c.SetData(ctx, []byte("same media"))
+	for _, name := range []string{"first.jpg", "second.jpg"} {
+		if err := c.Save(ctx, name); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+
+	var objects int
+	_ = filepath.WalkDir(filepath.Join(root, "objects"), func(p string, de os.DirEntry, err error) error {
+		if err == nil && !de.IsDir() {
+			objects++
+		}
+		return nil
+	})
+	if objects != 1 {
+		t.Fatalf("expected 1 stored object, got %d", objects)
+	}
+
+	got, err := c.Open(ctx, "first.jpg")
+	if err != nil || string(got) != "same media" {
+		t.Fatalf("Open = %q, %v", got, err)
+	}
+
+	sum := sha256.Sum256([]byte("same media"))
+	key := strings.ToUpper(hex.EncodeToString(sum[:]))
+	if !c.Has(key) {
+		t.Fatalf("Has(%s) = false", key)
+	}
+	if err := c.Link(ctx, key, "third.jpg"); err != nil {
+		t.Fatalf("Link: %v", err)
+	}
+	if got, err := c.Open(ctx, "third.jpg"); err != nil || string(got) != "same media" {
+		t.Fatalf("Open linked = %q, %v", got, err)
+	}
+	if got, err := c.Open(ctx, key); err != nil || string(got) != "same media" {
+		t.Fatalf("Open by sum = %q, %v", got, err)
+	}
+	if err := c.Link(ctx, strings.Repeat("0", 64), "x.jpg"); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("Link unknown sum: want fs.ErrNotExist, got %v", err)
+	}
+}
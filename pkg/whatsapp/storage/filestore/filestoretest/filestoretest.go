@@ -0,0 +1,117 @@
+// Package filestoretest provides a conformance suite for ports.FileManagerAPI
+// implementations.
+//
+//	func TestMyStore(t *testing.T) {
+//		filestoretest.Run(t, func(t *testing.T) ports.FileManagerAPI { return newMyStore(t.TempDir()) })
+//	}
+package filestoretest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/fs"
+	"testing"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/ports"
+)
+
+// Run checks the behaviour MediaService relies on. newFM must return a new,
+// empty instance on every call.
+func Run(t *testing.T, newFM func(t *testing.T) ports.FileManagerAPI) {
+	t.Helper()
+	ctx := context.Background()
+
+	t.Run("SaveOpenRoundTrip", func(t *testing.T) {
+		fm := newFM(t)
+		data := []byte("\x00\x01binary\xffcontent")
+		mustSetData(t, fm, data)
+		// The staged data must be a copy.
+		data[0] = 'X'
+		if err := fm.Save(ctx, "photo.jpg"); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		got, err := fm.Open(ctx, "photo.jpg")
+		if err != nil {
+			t.Fatalf("Open: %v", err)
+		}
+		if !bytes.Equal(got, []byte("\x00\x01binary\xffcontent")) {
+			t.Fatalf("Open = %q", got)
+		}
+	})
+
+	t.Run("Overwrite", func(t *testing.T) {
+		fm := newFM(t)
+		mustSetData(t, fm, []byte("v1"))
+		if err := fm.Save(ctx, "doc.pdf"); err != nil {
+			t.Fatalf("Save v1: %v", err)
+		}
+		mustSetData(t, fm, []byte("v2"))
+		if err := fm.Save(ctx, "doc.pdf"); err != nil {
+			t.Fatalf("Save v2: %v", err)
+		}
+		if got, err := fm.Open(ctx, "doc.pdf"); err != nil || string(got) != "v2" {
+			t.Fatalf("Open = %q, %v", got, err)
+		}
+	})
+
+	t.Run("SameDataManyNames", func(t *testing.T) {
+		fm := newFM(t)
+		mustSetData(t, fm, []byte("shared"))
+		for _, name := range []string{"a.txt", "b.txt"} {
+			if err := fm.Save(ctx, name); err != nil {
+				t.Fatalf("Save %s: %v", name, err)
+			}
+		}
+		for _, name := range []string{"a.txt", "b.txt"} {
+			if got, err := fm.Open(ctx, name); err != nil || string(got) != "shared" {
+				t.Fatalf("Open %s = %q, %v", name, got, err)
+			}
+		}
+	})
+
+	t.Run("EmptyData", func(t *testing.T) {
+		fm := newFM(t)
+		mustSetData(t, fm, []byte{})
+		if err := fm.Save(ctx, "empty.bin"); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		if got, err := fm.Open(ctx, "empty.bin"); err != nil || len(got) != 0 {
+			t.Fatalf("Open = %q, %v", got, err)
+		}
+	})
+
+	t.Run("OpenMissing", func(t *testing.T) {
+		fm := newFM(t)
+		if _, err := fm.Open(ctx, "missing.ogg"); !errors.Is(err, fs.ErrNotExist) {
+			t.Fatalf("Open missing: want fs.ErrNotExist, got %v", err)
+		}
+	})
+
+	t.Run("SaveWithoutData", func(t *testing.T) {
+		fm := newFM(t)
+		if err := fm.Save(ctx, "nothing.bin"); err == nil {
+			t.Fatalf("Save without SetData should fail")
+		}
+	})
+
+	t.Run("CanceledContext", func(t *testing.T) {
+		fm := newFM(t)
+		cctx, cancel := context.WithCancel(ctx)
+		cancel()
+		if err := fm.SetData(cctx, []byte("x")); err == nil {
+			t.Fatalf("SetData with canceled context should fail")
+		}
+		mustSetData(t, fm, []byte("x"))
+		if err := fm.Save(cctx, "x.bin"); err == nil {
+			t.Fatalf("Save with canceled context should fail")
+		}
+	})
+}
+
+func mustSetData(t *testing.T, fm ports.FileManagerAPI, data []byte) {
+	t.Helper()
+	if err := fm.SetData(context.Background(), data); err != nil {
+		t.Fatalf("SetData: %v", err)
+	}
+}
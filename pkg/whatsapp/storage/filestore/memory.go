@@ -0,0 +1,52 @@
+package filestore
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"sync"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/ports"
+)
+
+// Memory is an in-memory FileManagerAPI.
+type Memory struct {
+	staged
+
+	mu    sync.RWMutex
+	files map[string][]byte
+}
+
+var _ ports.FileManagerAPI = (*Memory)(nil)
+
+func NewMemory() *Memory { return &Memory{files: map[string][]byte{}} }
+
+func (m *Memory) Save(ctx context.Context, fileName string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if fileName == "" {
+		return ErrInvalidName
+	}
+	data, err := m.take()
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	m.files[fileName] = data
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *Memory) Open(ctx context.Context, fileName string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	m.mu.RLock()
+	data, ok := m.files[fileName]
+	m.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("open %s: %w", fileName, fs.ErrNotExist)
+	}
+	return append([]byte(nil), data...), nil
+}
@@ -0,0 +1,43 @@
+package filestore
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var (
+	// ErrNoData is returned by Save when SetData was not called first.
+	ErrNoData = errors.New("filestore: no data staged")
+	// ErrInvalidName is returned for names that are empty after sanitisation.
+	ErrInvalidName = errors.New("filestore: invalid file name")
+)
+
+// staged holds the bytes passed to SetData until the next Save.
+type staged struct {
+	mu   sync.Mutex
+	data []byte
+	ok   bool
+}
+
+func (s *staged) SetData(ctx context.Context, data []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.data = append(make([]byte, 0, len(data)), data...)
+	s.ok = true
+	s.mu.Unlock()
+	return nil
+}
+
+// take returns the staged data; it stays staged so it can be saved under
+// several names.
+func (s *staged) take() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.ok {
+		return nil, ErrNoData
+	}
+	return s.data, nil
+}
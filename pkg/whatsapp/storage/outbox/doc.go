@@ -0,0 +1,9 @@
+// Package outbox provides ready-made ports.OutboxStore implementations:
+//
+//   - Memory keeps items in a map, for tests and single-process setups that
+//     can afford to lose pending items on restart.
+//   - File keeps the same index in memory and journals every change to an
+//     append-only file, so pending items survive crashes and restarts.
+//
+// Items are drained by services.OutboxWorker.
+package outbox
@@ -0,0 +1,228 @@
+package outbox
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/ports"
+)
+
+// File is an OutboxStore backed by a journal file. Items are indexed in memory;
+// every change is appended to the journal as one JSON line and synced before
+// the call returns. OpenFile replays the journal, ignoring a torn last line
+// left by a crash, and items that were claimed when the process died are
+// claimed again once their lease expires. The journal is compacted when it
+// holds many superseded records.
+type File struct {
+	mu      sync.Mutex
+	x       index
+	path    string
+	f       *os.File
+	records int // lines in the journal
+}
+
+var _ ports.OutboxStore = (*File)(nil)
+
+// journalRecord is one journal line: an item state, or a removed key.
+type journalRecord struct {
+	Item    *domain.OutboxItem `json:"item,omitempty"`
+	Removed string             `json:"removed,omitempty"`
+}
+
+// OpenFile opens, or creates, the journal at path.
+func OpenFile(path string) (*File, error) {
+	s := &File{x: newIndex(), path: path}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	if err := s.compact(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *File) load() error {
+	f, err := os.Open(s.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("outbox: open journal: %w", err)
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64<<10), 16<<20)
+	for sc.Scan() {
+		var rec journalRecord
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+			continue // torn write
+		}
+		switch {
+		case rec.Item != nil:
+			s.x.put(*rec.Item)
+		case rec.Removed != "":
+			s.x.purgeKey(rec.Removed)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return fmt.Errorf("outbox: read journal: %w", err)
+	}
+	return nil
+}
+
+// compact rewrites the journal with one record per live item.
+func (s *File) compact() (err error) {
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), "."+filepath.Base(s.path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("outbox: create temp: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	w := bufio.NewWriter(tmp)
+	enc := json.NewEncoder(w)
+	for _, k := range s.x.order {
+		if err = enc.Encode(journalRecord{Item: s.x.items[k]}); err != nil {
+			return fmt.Errorf("outbox: encode: %w", err)
+		}
+	}
+	if err = w.Flush(); err != nil {
+		return fmt.Errorf("outbox: write: %w", err)
+	}
+	if err = tmp.Sync(); err != nil {
+		return fmt.Errorf("outbox: sync: %w", err)
+	}
+	if err = os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("outbox: rename: %w", err)
+	}
+	if s.f != nil {
+		_ = s.f.Close()
+	}
+	s.f = tmp
+	s.records = len(s.x.order)
+	return nil
+}
+
+// append journals recs and compacts the journal when it has grown well past
+// the number of live items.
+func (s *File) append(recs ...journalRecord) error {
+	var b []byte
+	for _, r := range recs {
+		line, err := json.Marshal(r)
+		if err != nil {
+			return fmt.Errorf("outbox: encode: %w", err)
+		}
+		b = append(append(b, line...), '\n')
+	}
+	if _, err := s.f.Write(b); err != nil {
+		return fmt.Errorf("outbox: write: %w", err)
+	}
+	if err := s.f.Sync(); err != nil {
+		return fmt.Errorf("outbox: sync: %w", err)
+	}
+	s.records += len(recs)
+	if s.records > 1024 && s.records > 4*len(s.x.order) {
+		return s.compact()
+	}
+	return nil
+}
+
+func (s *File) Enqueue(ctx context.Context, item domain.OutboxItem) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	it, added, err := s.x.enqueue(item)
+	if err != nil || !added {
+		return false, err
+	}
+	if err := s.append(journalRecord{Item: &it}); err != nil {
+		s.x.purgeKey(it.Key)
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *File) Claim(ctx context.Context, n int, lease time.Duration) ([]domain.OutboxItem, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	items, err := s.x.claim(n, lease)
+	if len(items) == 0 {
+		return nil, err
+	}
+	recs := make([]journalRecord, len(items))
+	for i := range items {
+		recs[i] = journalRecord{Item: &items[i]}
+	}
+	// Should the write fail, the in-memory lease still prevents double
+	// claims in this process; after a restart the items are simply due again.
+	if jerr := s.append(recs...); jerr != nil {
+		return items, jerr
+	}
+	return items, err
+}
+
+func (s *File) MarkSent(ctx context.Context, key, wamid string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	it, err := s.x.markSent(key, wamid)
+	if err != nil {
+		return err
+	}
+	return s.append(journalRecord{Item: &it})
+}
+
+func (s *File) MarkFailed(ctx context.Context, key, cause string, retryAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	it, changed, err := s.x.markFailed(key, cause, retryAt)
+	if err != nil || !changed {
+		return err
+	}
+	return s.append(journalRecord{Item: &it})
+}
+
+func (s *File) Get(ctx context.Context, key string) (domain.OutboxItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.x.get(key)
+}
+
+// Purge removes sent and permanently failed items last updated before t, and
+// returns how many were removed.
+func (s *File) Purge(ctx context.Context, t time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	removed := s.x.purge(t)
+	if len(removed) == 0 {
+		return 0, nil
+	}
+	recs := make([]journalRecord, len(removed))
+	for i, k := range removed {
+		recs[i] = journalRecord{Removed: k}
+	}
+	return len(removed), s.append(recs...)
+}
+
+// Close closes the journal.
+func (s *File) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.f.Close()
+}
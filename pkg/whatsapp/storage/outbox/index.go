@@ -0,0 +1,176 @@
+package outbox
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
+)
+
+var (
+	// ErrNotFound is returned for keys that are not in the outbox.
+	ErrNotFound = errors.New("outbox: item not found")
+	// ErrInvalidItem is returned by Enqueue for items without Key or Message.
+	ErrInvalidItem = errors.New("outbox: item needs a key and a message")
+)
+
+// index holds the items of a store in enqueue order. It is not safe for
+// concurrent use; stores guard it with their own lock. Every method that
+// changes an item returns copies of the changed items so File can journal them.
+// Messages are deep-copied on the way in (enqueue) and out (claim, get): the
+// sender normalizes the message it is given, and must not do so on the
+// index's copy while a store reads or journals it.
+type index struct {
+	items map[string]*domain.OutboxItem
+	order []string
+	now   func() time.Time
+}
+
+func newIndex() index {
+	return index{items: map[string]*domain.OutboxItem{}, now: time.Now}
+}
+
+func (x *index) put(it domain.OutboxItem) {
+	if _, ok := x.items[it.Key]; !ok {
+		x.order = append(x.order, it.Key)
+	}
+	x.items[it.Key] = &it
+}
+
+func (x *index) enqueue(it domain.OutboxItem) (domain.OutboxItem, bool, error) {
+	if it.Key == "" || it.Message == nil {
+		return it, false, ErrInvalidItem
+	}
+	if _, ok := x.items[it.Key]; ok {
+		return it, false, nil
+	}
+	msg, err := cloneMessage(it.Message)
+	if err != nil {
+		return it, false, fmt.Errorf("enqueue %q: %w", it.Key, err)
+	}
+	it.Message = msg
+	now := x.now()
+	it.Status, it.Attempts, it.WAMID, it.LastError = domain.OutboxPending, 0, "", ""
+	it.LeaseUntil, it.CreatedAt, it.UpdatedAt = time.Time{}, now, now
+	x.put(it)
+	return it, true, nil
+}
+
+func (x *index) claim(n int, lease time.Duration) ([]domain.OutboxItem, error) {
+	now := x.now()
+	var out []domain.OutboxItem
+	for _, k := range x.order {
+		if len(out) >= n {
+			break
+		}
+		it := x.items[k]
+		due := it.Status == domain.OutboxPending && !it.NotBefore.After(now)
+		expired := it.Status == domain.OutboxClaimed && !it.LeaseUntil.After(now)
+		if !due && !expired {
+			continue
+		}
+		it.Status = domain.OutboxClaimed
+		it.Attempts++
+		it.LeaseUntil = now.Add(lease)
+		it.UpdatedAt = now
+		c, err := copyItem(it)
+		if err != nil {
+			return out, err
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+func (x *index) markSent(key, wamid string) (domain.OutboxItem, error) {
+	it, ok := x.items[key]
+	if !ok {
+		return domain.OutboxItem{}, fmt.Errorf("mark sent %q: %w", key, ErrNotFound)
+	}
+	it.Status, it.WAMID, it.LastError = domain.OutboxSent, wamid, ""
+	it.LeaseUntil, it.NotBefore, it.UpdatedAt = time.Time{}, time.Time{}, x.now()
+	return *it, nil
+}
+
+func (x *index) markFailed(key, cause string, retryAt time.Time) (domain.OutboxItem, bool, error) {
+	it, ok := x.items[key]
+	if !ok {
+		return domain.OutboxItem{}, false, fmt.Errorf("mark failed %q: %w", key, ErrNotFound)
+	}
+	if it.Status == domain.OutboxSent {
+		// A late failure from an expired lease must not undo a send.
+		return *it, false, nil
+	}
+	it.Status, it.LastError, it.NotBefore = domain.OutboxFailed, cause, time.Time{}
+	if !retryAt.IsZero() {
+		it.Status, it.NotBefore = domain.OutboxPending, retryAt
+	}
+	it.LeaseUntil, it.UpdatedAt = time.Time{}, x.now()
+	return *it, true, nil
+}
+
+func (x *index) get(key string) (domain.OutboxItem, error) {
+	it, ok := x.items[key]
+	if !ok {
+		return domain.OutboxItem{}, fmt.Errorf("get %q: %w", key, ErrNotFound)
+	}
+	return copyItem(it)
+}
+
+// copyItem returns a copy of it with its own Message.
+func copyItem(it *domain.OutboxItem) (domain.OutboxItem, error) {
+	c := *it
+	msg, err := cloneMessage(it.Message)
+	if err != nil {
+		return domain.OutboxItem{}, fmt.Errorf("copy %q: %w", it.Key, err)
+	}
+	c.Message = msg
+	return c, nil
+}
+
+// cloneMessage deep-copies m through its JSON form, the same form File
+// journals it in.
+func cloneMessage(m *domain.SendMessage) (*domain.SendMessage, error) {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("encode message: %w", err)
+	}
+	var c domain.SendMessage
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("decode message: %w", err)
+	}
+	return &c, nil
+}
+
+// purgeKey drops key regardless of its status.
+func (x *index) purgeKey(key string) {
+	if _, ok := x.items[key]; !ok {
+		return
+	}
+	delete(x.items, key)
+	for i, k := range x.order {
+		if k == key {
+			x.order = append(x.order[:i], x.order[i+1:]...)
+			break
+		}
+	}
+}
+
+// purge drops sent and failed items last updated before t and returns their keys.
+func (x *index) purge(t time.Time) []string {
+	var removed []string
+	kept := x.order[:0]
+	for _, k := range x.order {
+		it := x.items[k]
+		if (it.Status == domain.OutboxSent || it.Status == domain.OutboxFailed) && it.UpdatedAt.Before(t) {
+			delete(x.items, k)
+			removed = append(removed, k)
+			continue
+		}
+		kept = append(kept, k)
+	}
+	x.order = kept
+	return removed
+}
@@ -0,0 +1,68 @@
+package outbox
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/ports"
+)
+
+// Memory is an in-memory OutboxStore. Like File, it records MarkSent and
+// MarkFailed even when ctx is already canceled, so outcomes are not lost.
+type Memory struct {
+	mu sync.Mutex
+	x  index
+}
+
+var _ ports.OutboxStore = (*Memory)(nil)
+
+func NewMemory() *Memory { return &Memory{x: newIndex()} }
+
+func (m *Memory) Enqueue(ctx context.Context, item domain.OutboxItem) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, added, err := m.x.enqueue(item)
+	return added, err
+}
+
+func (m *Memory) Claim(ctx context.Context, n int, lease time.Duration) ([]domain.OutboxItem, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.x.claim(n, lease)
+}
+
+func (m *Memory) MarkSent(ctx context.Context, key, wamid string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, err := m.x.markSent(key, wamid)
+	return err
+}
+
+func (m *Memory) MarkFailed(ctx context.Context, key, cause string, retryAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, _, err := m.x.markFailed(key, cause, retryAt)
+	return err
+}
+
+func (m *Memory) Get(ctx context.Context, key string) (domain.OutboxItem, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.x.get(key)
+}
+
+// Purge removes sent and permanently failed items last updated before t, and
+// returns how many were removed.
+func (m *Memory) Purge(ctx context.Context, t time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.x.purge(t)), nil
+}
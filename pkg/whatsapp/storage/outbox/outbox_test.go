@@ -0,0 +1,368 @@
+package outbox_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/ports"
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/storage/outbox"
+)
+
+// stores lists the implementations the behaviour services.OutboxWorker relies
+// on is checked against; open returns a new, empty store.
+var stores = []struct {
+	name string
+	open func(t *testing.T) ports.OutboxStore
+}{
+	{"Memory", func(t *testing.T) ports.OutboxStore { return outbox.NewMemory() }},
+	{"File", func(t *testing.T) ports.OutboxStore { return openFile(t, filepath.Join(t.TempDir(), "outbox.jsonl")) }},
+}
+
+func TestStores_EnqueueIsIdempotent(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range stores {
+		t.Run(tc.name, func(t *testing.T) {
+			s := tc.open(t)
+			if !mustEnqueue(t, s, "k1") {
+				t.Fatalf("first Enqueue not added")
+			}
+			if mustEnqueue(t, s, "k1") {
+				t.Fatalf("duplicate key added")
+			}
+			if _, err := s.Enqueue(ctx, domain.OutboxItem{Message: text("1")}); err == nil {
+				t.Fatalf("expected error for empty key")
+			}
+			it, err := s.Get(ctx, "k1")
+			if err != nil || it.Status != domain.OutboxPending || it.Attempts != 0 || it.CreatedAt.IsZero() {
+				t.Fatalf("Get = %+v, %v", it, err)
+			}
+			if _, err := s.Get(ctx, "missing"); err == nil {
+				t.Fatalf("expected error for missing key")
+			}
+		})
+	}
+}
+
+func TestStores_MessageRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	messages := []struct {
+		key string
+		msg *domain.SendMessage
+	}{
+		{"text", text("hello")},
+		{"template", domain.NewTemplateBuilder("order_update", "en_US").BodyText("Ana", "42").Message("5511999999999")},
+	}
+	for _, tc := range stores {
+		t.Run(tc.name, func(t *testing.T) {
+			s := tc.open(t)
+			for _, m := range messages {
+				if _, err := s.Enqueue(ctx, domain.OutboxItem{Key: m.key, Message: m.msg}); err != nil {
+					t.Fatalf("Enqueue: %v", err)
+				}
+				it, err := s.Get(ctx, m.key)
+				if err != nil {
+					t.Fatalf("Get: %v", err)
+				}
+				want, _ := json.Marshal(m.msg)
+				got, _ := json.Marshal(it.Message)
+				if !bytes.Equal(got, want) {
+					t.Fatalf("%s message changed:\n got %s\nwant %s", m.key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestStores_ClaimLeasesOldestFirst(t *testing.T) {
+	for _, tc := range stores {
+		t.Run(tc.name, func(t *testing.T) {
+			s := tc.open(t)
+			for i := 0; i < 3; i++ {
+				mustEnqueue(t, s, fmt.Sprint("k", i))
+			}
+			items := mustClaim(t, s, 2, time.Minute)
+			if len(items) != 2 || items[0].Key != "k0" || items[1].Key != "k1" {
+				t.Fatalf("Claim = %+v", keys(items))
+			}
+			if items[0].Status != domain.OutboxClaimed || items[0].Attempts != 1 || items[0].Message == nil {
+				t.Fatalf("claimed item = %+v", items[0])
+			}
+			if rest := mustClaim(t, s, 10, time.Minute); len(rest) != 1 || rest[0].Key != "k2" {
+				t.Fatalf("leased items claimed twice: %v", keys(rest))
+			}
+		})
+	}
+}
+
+func TestStores_ExpiredLeaseIsReclaimed(t *testing.T) {
+	for _, tc := range stores {
+		t.Run(tc.name, func(t *testing.T) {
+			s := tc.open(t)
+			mustEnqueue(t, s, "k")
+			mustClaim(t, s, 1, 10*time.Millisecond)
+			time.Sleep(20 * time.Millisecond)
+			items := mustClaim(t, s, 1, time.Minute)
+			if len(items) != 1 || items[0].Attempts != 2 {
+				t.Fatalf("expired lease not reclaimed: %+v", items)
+			}
+		})
+	}
+}
+
+func TestStores_MarkSent(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range stores {
+		t.Run(tc.name, func(t *testing.T) {
+			s := tc.open(t)
+			mustEnqueue(t, s, "k")
+			mustClaim(t, s, 1, 10*time.Millisecond)
+			if err := s.MarkSent(ctx, "k", "wamid.1"); err != nil {
+				t.Fatalf("MarkSent: %v", err)
+			}
+			// A late failure must not undo the send.
+			if err := s.MarkFailed(ctx, "k", "late", time.Time{}); err != nil {
+				t.Fatalf("MarkFailed: %v", err)
+			}
+			time.Sleep(20 * time.Millisecond)
+			if items := mustClaim(t, s, 1, time.Minute); len(items) != 0 {
+				t.Fatalf("sent item claimed again")
+			}
+			it, _ := s.Get(ctx, "k")
+			if it.Status != domain.OutboxSent || it.WAMID != "wamid.1" {
+				t.Fatalf("Get = %+v", it)
+			}
+			if err := s.MarkSent(ctx, "missing", "w"); err == nil {
+				t.Fatalf("expected error for missing key")
+			}
+		})
+	}
+}
+
+func TestStores_MarkFailed(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range stores {
+		t.Run(tc.name, func(t *testing.T) {
+			s := tc.open(t)
+			mustEnqueue(t, s, "retry")
+			mustEnqueue(t, s, "fatal")
+			mustClaim(t, s, 2, time.Minute)
+			if err := s.MarkFailed(ctx, "retry", "throttled", time.Now().Add(30*time.Millisecond)); err != nil {
+				t.Fatalf("MarkFailed: %v", err)
+			}
+			if err := s.MarkFailed(ctx, "fatal", "undeliverable", time.Time{}); err != nil {
+				t.Fatalf("MarkFailed: %v", err)
+			}
+			if items := mustClaim(t, s, 2, time.Minute); len(items) != 0 {
+				t.Fatalf("claimed before retryAt: %v", keys(items))
+			}
+			time.Sleep(40 * time.Millisecond)
+			items := mustClaim(t, s, 2, time.Minute)
+			if len(items) != 1 || items[0].Key != "retry" || items[0].LastError != "throttled" {
+				t.Fatalf("Claim after retryAt = %+v", items)
+			}
+			it, _ := s.Get(ctx, "fatal")
+			if it.Status != domain.OutboxFailed || it.LastError != "undeliverable" {
+				t.Fatalf("Get = %+v", it)
+			}
+		})
+	}
+}
+
+func TestStores_ConcurrentClaimsDoNotOverlap(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range stores {
+		t.Run(tc.name, func(t *testing.T) {
+			s := tc.open(t)
+			for i := 0; i < 50; i++ {
+				mustEnqueue(t, s, fmt.Sprint("k", i))
+			}
+			var (
+				mu   sync.Mutex
+				seen = map[string]int{}
+				wg   sync.WaitGroup
+			)
+			for w := 0; w < 5; w++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for {
+						items, err := s.Claim(ctx, 3, time.Minute)
+						if err != nil || len(items) == 0 {
+							return
+						}
+						mu.Lock()
+						for _, it := range items {
+							seen[it.Key]++
+						}
+						mu.Unlock()
+					}
+				}()
+			}
+			wg.Wait()
+			if len(seen) != 50 {
+				t.Fatalf("claimed %d distinct items, want 50", len(seen))
+			}
+			for k, n := range seen {
+				if n != 1 {
+					t.Fatalf("%s claimed %d times", k, n)
+				}
+			}
+		})
+	}
+}
+
+func openFile(t *testing.T, path string) *outbox.File {
+	t.Helper()
+	s, err := outbox.OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestFile_SurvivesRestart(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "outbox.jsonl")
+
+	s := openFile(t, path)
+	for _, k := range []string{"sent", "inflight", "pending"} {
+		mustEnqueue(t, s, k)
+	}
+	if _, err := s.Claim(ctx, 2, 20*time.Millisecond); err != nil {
+		t.Fatalf("Claim: %v", err)
+	}
+	if err := s.MarkSent(ctx, "sent", "wamid.1"); err != nil {
+		t.Fatalf("MarkSent: %v", err)
+	}
+	s.Close()
+
+	// Simulate a crash in the middle of a write.
+	f, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	f.WriteString(`{"item":{"key":"torn"`)
+	f.Close()
+
+	s = openFile(t, path)
+	if it, err := s.Get(ctx, "sent"); err != nil || it.Status != domain.OutboxSent || it.WAMID != "wamid.1" {
+		t.Fatalf("sent item = %+v, %v", it, err)
+	}
+	if _, err := s.Get(ctx, "torn"); err == nil {
+		t.Fatalf("torn record should be ignored")
+	}
+	if added, _ := s.Enqueue(ctx, domain.OutboxItem{Key: "pending", Message: domain.NewSendTextMessage("5511999999999", "again")}); added {
+		t.Fatalf("idempotency key lost across restart")
+	}
+
+	items, _ := s.Claim(ctx, 10, time.Minute)
+	if len(items) != 1 || items[0].Key != "pending" {
+		t.Fatalf("expected only the pending item while the lease is valid, got %d", len(items))
+	}
+	time.Sleep(30 * time.Millisecond)
+	items, _ = s.Claim(ctx, 10, time.Minute)
+	if len(items) != 1 || items[0].Key != "inflight" || items[0].Attempts != 2 {
+		t.Fatalf("in-flight item not redelivered after its lease: %+v", items)
+	}
+}
+
+func TestFile_PurgeAndCompaction(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "outbox.jsonl")
+	s := openFile(t, path)
+
+	mustEnqueue(t, s, "keep")
+	for i := 0; i < 600; i++ {
+		k := "done-" + string(rune('a'+i%26)) + string(rune('a'+i/26))
+		mustEnqueue(t, s, k)
+		s.Claim(ctx, 600, time.Minute)
+		s.MarkSent(ctx, k, "w")
+	}
+	n, err := s.Purge(ctx, time.Now().Add(time.Second))
+	if err != nil || n != 600 {
+		t.Fatalf("Purge = %d, %v", n, err)
+	}
+	s.Close()
+
+	st, _ := os.Stat(path)
+	if st.Size() > 4<<10 {
+		t.Fatalf("journal not compacted: %d bytes", st.Size())
+	}
+	s = openFile(t, path)
+	if it, err := s.Get(ctx, "keep"); err != nil || it.Status != domain.OutboxClaimed {
+		t.Fatalf("keep = %+v, %v", it, err)
+	}
+	if _, err := s.Get(ctx, "done-aa"); err == nil {
+		t.Fatalf("purged item came back")
+	}
+}
+
+func TestStores_MessagesAreCopied(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range stores {
+		t.Run(tc.name, func(t *testing.T) {
+			s := tc.open(t)
+			msg := domain.NewSendTextMessage("+55 11 99999-9999", "hi")
+			if _, err := s.Enqueue(ctx, domain.OutboxItem{Key: "k", Message: msg}); err != nil {
+				t.Fatalf("Enqueue: %v", err)
+			}
+			msg.To = "enqueued"
+
+			items, err := s.Claim(ctx, 1, time.Minute)
+			if err != nil || len(items) != 1 {
+				t.Fatalf("Claim = %v, %v", items, err)
+			}
+			// The sender normalizes the message it is given, concurrently
+			// with other store calls.
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				items[0].Message.To = "claimed"
+			}()
+			got, err := s.Get(ctx, "k")
+			<-done
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			got.Message.To = "got"
+
+			if it, _ := s.Get(ctx, "k"); it.Message.To != "+55 11 99999-9999" {
+				t.Fatalf("stored message changed: To = %q", it.Message.To)
+			}
+		})
+	}
+}
+
+func text(body string) *domain.SendMessage { return domain.NewSendTextMessage("5511999999999", body) }
+
+func mustEnqueue(t *testing.T, s ports.OutboxStore, key string) bool {
+	t.Helper()
+	added, err := s.Enqueue(context.Background(), domain.OutboxItem{Key: key, Message: text(key)})
+	if err != nil {
+		t.Fatalf("Enqueue(%s): %v", key, err)
+	}
+	return added
+}
+
+func mustClaim(t *testing.T, s ports.OutboxStore, n int, lease time.Duration) []domain.OutboxItem {
+	t.Helper()
+	items, err := s.Claim(context.Background(), n, lease)
+	if err != nil {
+		t.Fatalf("Claim: %v", err)
+	}
+	return items
+}
+
+func keys(items []domain.OutboxItem) []string {
+	ks := make([]string, len(items))
+	for i, it := range items {
+		ks[i] = it.Key
+	}
+	return ks
+}
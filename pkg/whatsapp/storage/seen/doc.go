@@ -0,0 +1,7 @@
+// Package seen provides ready-made ports.SeenStore implementations for
+// webhook deduplication (see services.WebhookDispatcher.WithDedup):
+//
+//   - LRU keeps keys in memory, bounded by count and expiring by TTL. It is
+//     enough for a single webhook receiver; several replicas behind a load
+//     balancer need a shared store (e.g. Redis SET NX EX) instead.
+package seen
@@ -0,0 +1,87 @@
+package seen
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/ports"
+)
+
+// DefaultCapacity is the number of keys an LRU holds when none is given.
+const DefaultCapacity = 100_000
+
+// LRU is an in-memory ports.SeenStore. A key counts as seen until its TTL,
+// measured from the first sighting, runs out or until it is evicted to make
+// room for newer keys, whichever happens first.
+type LRU struct {
+	mu    sync.Mutex
+	cap   int
+	order *list.List // front is most recently seen
+	keys  map[string]*list.Element
+}
+
+type lruEntry struct {
+	key     string
+	expires time.Time
+}
+
+var _ ports.SeenStore = (*LRU)(nil)
+
+// NewLRU returns an LRU holding up to capacity keys; capacity <= 0 selects
+// DefaultCapacity.
+func NewLRU(capacity int) *LRU {
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+	return &LRU{cap: capacity, order: list.New(), keys: map[string]*list.Element{}}
+}
+
+func (l *LRU) MarkSeen(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if el, ok := l.keys[key]; ok {
+		e := el.Value.(*lruEntry)
+		if now.Before(e.expires) {
+			l.order.MoveToFront(el)
+			return false, nil
+		}
+		e.expires = now.Add(ttl)
+		l.order.MoveToFront(el)
+		return true, nil
+	}
+
+	l.keys[key] = l.order.PushFront(&lruEntry{key: key, expires: now.Add(ttl)})
+	for l.order.Len() > l.cap {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.keys, oldest.Value.(*lruEntry).key)
+	}
+	return true, nil
+}
+
+func (l *LRU) Forget(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if el, ok := l.keys[key]; ok {
+		l.order.Remove(el)
+		delete(l.keys, key)
+	}
+	return nil
+}
+
+// Len returns how many keys are held, including expired ones not yet evicted.
+func (l *LRU) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.order.Len()
+}
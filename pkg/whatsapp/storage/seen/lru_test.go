@@ -0,0 +1,100 @@
+package seen_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/storage/seen"
+)
+
+func TestLRU_MarkSeen(t *testing.T) {
+	ctx := context.Background()
+	l := seen.NewLRU(10)
+
+	if first, err := l.MarkSeen(ctx, "a", time.Hour); err != nil || !first {
+		t.Fatalf("first sighting: %v %v", first, err)
+	}
+	if first, _ := l.MarkSeen(ctx, "a", time.Hour); first {
+		t.Fatal("second sighting reported as first")
+	}
+}
+
+func TestLRU_Expires(t *testing.T) {
+	ctx := context.Background()
+	l := seen.NewLRU(10)
+
+	_, _ = l.MarkSeen(ctx, "a", 10*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	if first, _ := l.MarkSeen(ctx, "a", time.Hour); !first {
+		t.Fatal("expired key still seen")
+	}
+	if first, _ := l.MarkSeen(ctx, "a", time.Hour); first {
+		t.Fatal("renewed key not seen")
+	}
+}
+
+func TestLRU_EvictsLeastRecentlySeen(t *testing.T) {
+	ctx := context.Background()
+	l := seen.NewLRU(2)
+
+	_, _ = l.MarkSeen(ctx, "a", time.Hour)
+	_, _ = l.MarkSeen(ctx, "b", time.Hour)
+	_, _ = l.MarkSeen(ctx, "a", time.Hour) // a is now the most recent
+	_, _ = l.MarkSeen(ctx, "c", time.Hour) // evicts b
+
+	if l.Len() != 2 {
+		t.Fatalf("len = %d, want 2", l.Len())
+	}
+	if first, _ := l.MarkSeen(ctx, "a", time.Hour); first {
+		t.Fatal("a was evicted")
+	}
+	if first, _ := l.MarkSeen(ctx, "b", time.Hour); !first {
+		t.Fatal("b was not evicted")
+	}
+}
+
+func TestLRU_Forget(t *testing.T) {
+	ctx := context.Background()
+	l := seen.NewLRU(10)
+
+	_, _ = l.MarkSeen(ctx, "a", time.Hour)
+	if err := l.Forget(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Forget(ctx, "missing"); err != nil {
+		t.Fatal(err)
+	}
+	if first, _ := l.MarkSeen(ctx, "a", time.Hour); !first {
+		t.Fatal("forgotten key still seen")
+	}
+}
+
+func TestLRU_ConcurrentFirstSighting(t *testing.T) {
+	l := seen.NewLRU(0)
+	var firsts atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if first, _ := l.MarkSeen(context.Background(), "k", time.Hour); first {
+				firsts.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	if firsts.Load() != 1 {
+		t.Fatalf("%d callers saw the key first, want 1", firsts.Load())
+	}
+}
+
+func TestLRU_CanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := seen.NewLRU(1).MarkSeen(ctx, "k", time.Hour); err == nil {
+		t.Fatal("expected context error")
+	}
+}
@@ -0,0 +1,81 @@
+package whatsapp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/errorsx"
+)
+
+// maxAuthErrorPeek bounds how much of an error body is read to look for code 190.
+const maxAuthErrorPeek = 64 << 10
+
+// refreshGroup runs at most one TokenProvider.Refresh at a time; callers that
+// arrive while a refresh is in flight wait for its result instead of starting
+// their own.
+type refreshGroup struct {
+	mu   sync.Mutex
+	call *refreshCall
+}
+
+type refreshCall struct {
+	done chan struct{}
+	err  error
+}
+
+func (g *refreshGroup) do(ctx context.Context, fn func(context.Context) error) error {
+	g.mu.Lock()
+	if c := g.call; c != nil {
+		g.mu.Unlock()
+		select {
+		case <-c.done:
+			return c.err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	c := &refreshCall{done: make(chan struct{})}
+	g.call = c
+	g.mu.Unlock()
+
+	// Waiters must not fail because the first caller's context ends.
+	c.err = fn(context.WithoutCancel(ctx))
+
+	g.mu.Lock()
+	g.call = nil
+	g.mu.Unlock()
+	close(c.done)
+	return c.err
+}
+
+// isAuthFailure reports whether resp rejects the access token: a 401 or a Graph
+// error with code 190. The peeked body is put back so callers can still decode it.
+func isAuthFailure(resp *http.Response) bool {
+	if resp.StatusCode == http.StatusUnauthorized {
+		return true
+	}
+	if resp.StatusCode < 400 || resp.StatusCode >= 500 || resp.Body == nil {
+		return false
+	}
+	head, _ := io.ReadAll(io.LimitReader(resp.Body, maxAuthErrorPeek))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(head), resp.Body), resp.Body}
+
+	var envelope struct {
+		Error struct {
+			Code int `json:"code"`
+		} `json:"error"`
+	}
+	return json.Unmarshal(head, &envelope) == nil && envelope.Error.Code == errorsx.ErrAccessTokenExpired.Code
+}
+
+// replayable reports whether req can be sent a second time.
+func replayable(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
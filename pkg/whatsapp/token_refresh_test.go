@@ -0,0 +1,193 @@
+package whatsapp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// rotatingTokenProvider hands out "tok-N" and bumps N on Refresh.
+type rotatingTokenProvider struct {
+	gen        atomic.Int32
+	refreshes  atomic.Int32
+	refreshErr error
+	delay      time.Duration
+}
+
+func (p *rotatingTokenProvider) Token(ctx context.Context) (string, error) {
+	return "tok-" + string(rune('0'+p.gen.Load())), nil
+}
+
+func (p *rotatingTokenProvider) Refresh(ctx context.Context) error {
+	p.refreshes.Add(1)
+	time.Sleep(p.delay)
+	if p.refreshErr != nil {
+		return p.refreshErr
+	}
+	p.gen.Add(1)
+	return nil
+}
+
+// authServer accepts only "Bearer tok-1" and answers other tokens with status/body.
+func authServer(t *testing.T, status int, body string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.Header.Get("Authorization") != "Bearer tok-1" {
+			w.WriteHeader(status)
+			io.WriteString(w, body)
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		w.Write(b)
+	}))
+	t.Cleanup(ts.Close)
+	return ts, &calls
+}
+
+func newRefreshClient(t *testing.T, baseURL string, tp *rotatingTokenProvider) *Client {
+	t.Helper()
+	o := validOpts()
+	o.TokenProvider = tp
+	o.BaseURL = baseURL
+	c, err := NewClient(o)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return c
+}
+
+func postReplayable(t *testing.T, url, payload string) *http.Request {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodPost, url, strings.NewReader(payload))
+	return req // NewRequest sets GetBody for *strings.Reader
+}
+
+func TestDo_RefreshesOn401AndReplays(t *testing.T) {
+	ts, calls := authServer(t, http.StatusUnauthorized, `{"error":{"code":190}}`)
+	tp := &rotatingTokenProvider{}
+	c := newRefreshClient(t, ts.URL, tp)
+
+	resp, err := c.Do(context.Background(), postReplayable(t, ts.URL, "hello"))
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	defer resp.Body.Close()
+	b, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || string(b) != "hello" {
+		t.Fatalf("expected replayed 200 echoing the body, got %d %q", resp.StatusCode, b)
+	}
+	if tp.refreshes.Load() != 1 || calls.Load() != 2 {
+		t.Fatalf("refreshes=%d calls=%d, want 1 and 2", tp.refreshes.Load(), calls.Load())
+	}
+}
+
+func TestDo_RefreshesOnGraphCode190(t *testing.T) {
+	ts, _ := authServer(t, http.StatusBadRequest, `{"error":{"message":"Session has expired","type":"OAuthException","code":190}}`)
+	tp := &rotatingTokenProvider{}
+	c := newRefreshClient(t, ts.URL, tp)
+
+	req, _ := http.NewRequest(http.MethodGet, ts.URL, nil)
+	resp, err := c.Do(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || tp.refreshes.Load() != 1 {
+		t.Fatalf("status=%d refreshes=%d", resp.StatusCode, tp.refreshes.Load())
+	}
+}
+
+func TestDo_OtherClientErrorsKeepBody(t *testing.T) {
+	const body = `{"error":{"message":"bad param","code":100}}`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		io.WriteString(w, body)
+	}))
+	defer ts.Close()
+	tp := &rotatingTokenProvider{}
+	c := newRefreshClient(t, ts.URL, tp)
+
+	req, _ := http.NewRequest(http.MethodGet, ts.URL, nil)
+	resp, err := c.Do(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	defer resp.Body.Close()
+	b, _ := io.ReadAll(resp.Body)
+	if string(b) != body || tp.refreshes.Load() != 0 {
+		t.Fatalf("body=%q refreshes=%d", b, tp.refreshes.Load())
+	}
+}
+
+func TestDo_RefreshIsSingleFlight(t *testing.T) {
+	ts, _ := authServer(t, http.StatusUnauthorized, `{}`)
+	tp := &rotatingTokenProvider{delay: 20 * time.Millisecond}
+	c := newRefreshClient(t, ts.URL, tp)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := c.Do(context.Background(), postReplayable(t, ts.URL, "x"))
+			if err != nil {
+				errs <- err
+				return
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				errs <- errors.New(resp.Status)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("request failed: %v", err)
+	}
+	if n := tp.refreshes.Load(); n != 1 {
+		t.Fatalf("expected a single refresh, got %d", n)
+	}
+}
+
+func TestDo_RefreshErrorIsReturned(t *testing.T) {
+	ts, calls := authServer(t, http.StatusUnauthorized, `{}`)
+	tp := &rotatingTokenProvider{refreshErr: errors.New("vault down")}
+	c := newRefreshClient(t, ts.URL, tp)
+
+	req, _ := http.NewRequest(http.MethodGet, ts.URL, nil)
+	_, err := c.Do(context.Background(), req)
+	if err == nil || !strings.Contains(err.Error(), "refresh token: vault down") {
+		t.Fatalf("expected refresh error, got %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("request must not be replayed after a failed refresh, calls=%d", calls.Load())
+	}
+}
+
+func TestDo_NoReplayWithoutGetBody(t *testing.T) {
+	ts, calls := authServer(t, http.StatusUnauthorized, `{}`)
+	tp := &rotatingTokenProvider{}
+	c := newRefreshClient(t, ts.URL, tp)
+
+	req, _ := http.NewRequest(http.MethodPost, ts.URL, io.NopCloser(bytes.NewBufferString("once")))
+	resp, err := c.Do(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized || tp.refreshes.Load() != 0 || calls.Load() != 1 {
+		t.Fatalf("status=%d refreshes=%d calls=%d", resp.StatusCode, tp.refreshes.Load(), calls.Load())
+	}
+}
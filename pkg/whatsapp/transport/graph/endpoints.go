@@ -3,6 +3,7 @@ package graph
 import (
 	"net/url"
 	"path"
+	"strings"
 )
 
 const DefaultBaseURL = "https://graph.facebook.com"
@@ -63,3 +64,29 @@ func VerifyCodeEndpoint(base, version, phoneNumberID string) string {
 func TwoFactorEndpoint(base, version, phoneNumberID string) string {
 	return buildURL(base, version, phoneNumberID)
 }
+
+// MessageTemplatesEndpoint returns the full URL for /{Version}/{WABA-ID}/message_templates.
+func MessageTemplatesEndpoint(base, version, wabaID string) string {
+	return buildURL(base, version, wabaID, "message_templates")
+}
+
+// MessageTemplateEndpoint returns the full URL for /{Version}/{Template-ID}.
+func MessageTemplateEndpoint(base, version, templateID string) string {
+	return buildURL(base, version, templateID)
+}
+
+// UploadSessionsEndpoint returns the full URL for POST /{Version}/{App-ID}/uploads.
+func UploadSessionsEndpoint(base, version, appID string) string {
+	return buildURL(base, version, appID, "uploads")
+}
+
+// UploadSessionEndpoint returns the full URL for /{Version}/{Upload-Session-ID}.
+// Session IDs may carry their own query ("upload:...?sig=..."), which is kept as-is.
+func UploadSessionEndpoint(base, version, sessionID string) string {
+	id, query, _ := strings.Cut(sessionID, "?")
+	u := buildURL(base, version, id)
+	if query != "" {
+		u += "?" + query
+	}
+	return u
+}
@@ -25,6 +25,10 @@ func TestBuildURLAndEndpoints(t *testing.T) {
 		{"requestCode", RequestCodeEndpoint(base, version, phoneID), "https://graph.example.com/v1/123/request_code"},
 		{"verifyCode", VerifyCodeEndpoint(base, version, phoneID), "https://graph.example.com/v1/123/verify_code"},
 		{"twoFactor", TwoFactorEndpoint(base, version, phoneID), "https://graph.example.com/v1/123"},
+		{"messageTemplates", MessageTemplatesEndpoint(base, version, wabaID), "https://graph.example.com/v1/waba/message_templates"},
+		{"messageTemplate", MessageTemplateEndpoint(base, version, "tpl"), "https://graph.example.com/v1/tpl"},
+		{"uploadSessions", UploadSessionsEndpoint(base, version, "app"), "https://graph.example.com/v1/app/uploads"},
+		{"uploadSession", UploadSessionEndpoint(base, version, "upload:MTpm?sig=ARZ"), "https://graph.example.com/v1/upload:MTpm?sig=ARZ"},
 	}
 
 	for _, tt := range cases {
@@ -7,7 +7,6 @@ import (
 	"io"
 	"mime/multipart"
 	"net/http"
-	"os"
 
 	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/utils"
 	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
@@ -21,38 +20,68 @@ func NewMediaAPI() ports.MediaAPI {
 	return &MediaAPI{}
 }
 
-func (m *MediaAPI) Upload(ctx context.Context, base, version, phoneNumberID string, f *os.File, tokenProvider ports.TokenProvider) (*http.Request, error) {
-	defer func() {
-		_ = f.Close()
-	}()
-
+// Upload builds a multipart upload that streams r instead of buffering it. When
+// mimeType is empty it is detected from filename and the first bytes of r.
+//
+// If r is an io.Seeker the request gets a Content-Length and a GetBody that
+// rewinds r, so it can be retried; other readers are sent chunked and only once.
+// r is never closed.
+func (m *MediaAPI) Upload(ctx context.Context, base, version, phoneNumberID string, r io.Reader, filename, mimeType string, tokenProvider ports.TokenProvider) (*http.Request, error) {
 	token, err := tokenProvider.Token(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("token: %w", err)
 	}
 
-	url := RequestMediaUpload(base, version, phoneNumberID)
+	if mimeType == "" {
+		if mimeType, r, err = utils.DetectMimeType(filename, r); err != nil {
+			return nil, fmt.Errorf("mime type: %w", err)
+		}
+	}
+
+	// Render everything around the file content up front; the content itself is
+	// streamed between the two.
 	buf := &bytes.Buffer{}
 	writer := multipart.NewWriter(buf)
-
 	_ = writer.WriteField("messaging_product", "whatsapp")
-
-	part, err := writer.CreatePart(utils.GetMimeTypeHeader(f))
-	if err != nil {
-		_ = writer.Close()
+	_ = writer.WriteField("type", mimeType)
+	if _, err := writer.CreatePart(utils.FilePartHeader(filename, mimeType)); err != nil {
 		return nil, fmt.Errorf("writer: %w", err)
 	}
-	if _, err := io.Copy(part, f); err != nil {
-		_ = writer.Close()
-		return nil, err
-	}
-
+	head := bytes.Clone(buf.Bytes())
+	buf.Reset()
 	_ = writer.Close()
+	tail := bytes.Clone(buf.Bytes())
+
+	body := func(content io.Reader) io.Reader {
+		return io.MultiReader(bytes.NewReader(head), content, bytes.NewReader(tail))
+	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, RequestMediaUpload(base, version, phoneNumberID), body(r))
 	if err != nil {
 		return nil, err
 	}
+
+	if rs, ok := r.(io.ReadSeeker); ok {
+		start, err := rs.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, fmt.Errorf("seek: %w", err)
+		}
+		end, err := rs.Seek(0, io.SeekEnd)
+		if err != nil {
+			return nil, fmt.Errorf("seek: %w", err)
+		}
+		if _, err := rs.Seek(start, io.SeekStart); err != nil {
+			return nil, fmt.Errorf("seek: %w", err)
+		}
+		req.ContentLength = int64(len(head)) + (end - start) + int64(len(tail))
+		req.GetBody = func() (io.ReadCloser, error) {
+			if _, err := rs.Seek(start, io.SeekStart); err != nil {
+				return nil, err
+			}
+			return io.NopCloser(body(rs)), nil
+		}
+	}
+
 	req.Header.Set("Authorization", "Bearer "+token)
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 
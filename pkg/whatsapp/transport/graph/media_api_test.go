@@ -0,0 +1,93 @@
+package graph
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+
+	portstesting "github.com/diegoyosiura/whatsapp-sdk-go/internal/testutils/whatsapp/ports"
+)
+
+// readUploadPart parses the multipart body of req and returns the form fields
+// and the "file" part.
+func readUploadPart(t *testing.T, req *http.Request, body io.Reader) (map[string]string, *multipart.Part, []byte) {
+	t.Helper()
+	_, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatalf("content type: %v", err)
+	}
+	mr := multipart.NewReader(body, params["boundary"])
+	fields := map[string]string{}
+	for {
+		p, err := mr.NextPart()
+		if err == io.EOF {
+			t.Fatalf("file part not found")
+		}
+		if err != nil {
+			t.Fatalf("next part: %v", err)
+		}
+		b, _ := io.ReadAll(p)
+		if p.FormName() == "file" {
+			return fields, p, b
+		}
+		fields[p.FormName()] = string(b)
+	}
+}
+
+func TestMediaAPIUpload_Seekable(t *testing.T) {
+	content := append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte("x"), 2048)...)
+	tp := &portstesting.FakeTokenProvider{TokenValue: "tok"}
+
+	req, err := NewMediaAPI().Upload(context.Background(), DefaultBaseURL, "v1", "pn", bytes.NewReader(content), "dir/pic.png", "", tp)
+	if err != nil {
+		t.Fatalf("Upload error: %v", err)
+	}
+	raw, _ := io.ReadAll(req.Body)
+	if req.ContentLength != int64(len(raw)) {
+		t.Fatalf("ContentLength %d, body %d", req.ContentLength, len(raw))
+	}
+
+	fields, part, got := readUploadPart(t, req, bytes.NewReader(raw))
+	if !bytes.Equal(got, content) {
+		t.Fatalf("file content changed: got %d bytes, want %d", len(got), len(content))
+	}
+	if part.FileName() != "pic.png" || part.Header.Get("Content-Type") != "image/png" {
+		t.Fatalf("unexpected part header %v", part.Header)
+	}
+	if fields["messaging_product"] != "whatsapp" || fields["type"] != "image/png" {
+		t.Fatalf("unexpected fields %v", fields)
+	}
+
+	rc, err := req.GetBody()
+	if err != nil {
+		t.Fatalf("GetBody error: %v", err)
+	}
+	again, _ := io.ReadAll(rc)
+	if !bytes.Equal(again, raw) {
+		t.Fatalf("GetBody mismatch")
+	}
+}
+
+func TestMediaAPIUpload_StreamExplicitMime(t *testing.T) {
+	content := strings.Repeat("video-bytes", 100)
+	// A plain io.Reader (no Seek) is streamed once, without a known length.
+	src := io.MultiReader(strings.NewReader(content))
+	tp := &portstesting.FakeTokenProvider{TokenValue: "tok"}
+
+	req, err := NewMediaAPI().Upload(context.Background(), DefaultBaseURL, "v1", "pn", src, "clip.mp4", "video/mp4", tp)
+	if err != nil {
+		t.Fatalf("Upload error: %v", err)
+	}
+	if req.GetBody != nil || req.ContentLength != 0 {
+		t.Fatalf("non-seekable body must not be rewindable")
+	}
+	_, part, got := readUploadPart(t, req, req.Body)
+	if string(got) != content || part.Header.Get("Content-Type") != "video/mp4" {
+		t.Fatalf("unexpected part %v (%d bytes)", part.Header, len(got))
+	}
+}
@@ -64,10 +64,8 @@ func (a *PhoneAPI) List(ctx context.Context) (*domain.PhoneList, error) {
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode/100 != 2 {
-		var any map[string]any
-		_ = json.NewDecoder(resp.Body).Decode(&any)
-		return nil, fmt.Errorf("graph error %d: %v", resp.StatusCode, any)
+	if err := CheckResponse(resp); err != nil {
+		return nil, err
 	}
 
 	var out domain.PhoneList
@@ -94,10 +92,8 @@ func (a *PhoneAPI) Get(ctx context.Context, phoneID string) (*domain.Phone, erro
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode/100 != 2 {
-		var any map[string]any
-		_ = json.NewDecoder(resp.Body).Decode(&any)
-		return nil, fmt.Errorf("graph error %d: %v", resp.StatusCode, any)
+	if err := CheckResponse(resp); err != nil {
+		return nil, err
 	}
 
 	var out domain.Phone
@@ -5,10 +5,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 
-	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/errorsx"
 	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
 	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/ports"
 )
@@ -131,21 +129,14 @@ func (a *RegistrationAPI) decodeActionResult(req *http.Request) (*domain.ActionR
 	}
 	defer resp.Body.Close()
 
-	// The Graph API normally replies { "success": true } for these endpoints.
-	dec := json.NewDecoder(resp.Body)
-	var out domain.ActionResult
-	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-		if err := dec.Decode(&out); err != nil {
-			return nil, fmt.Errorf("decode success: %w", err)
-		}
-		return &out, nil
+	if err := CheckResponse(resp); err != nil {
+		return nil, err
 	}
 
-	// Non-2xx: try to decode Graph error envelope, or fall back.
-	// TODO: if you have a GraphError type + DecodeGraphError, use it here.
-	body, _ := io.ReadAll(resp.Body)
-	if ge := errorsx.TryParseGraphError(resp, body); ge != nil {
-		return nil, ge
+	// The Graph API normally replies { "success": true } for these endpoints.
+	var out domain.ActionResult
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("decode success: %w", err)
 	}
-	return nil, errorsx.NewHTTPErrorFromResponse(resp, body)
+	return &out, nil
 }
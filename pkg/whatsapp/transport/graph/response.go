@@ -0,0 +1,31 @@
+package graph
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/errorsx"
+)
+
+// MaxErrorBodySize bounds how much of a non-2xx body is read into the returned
+// error, so a large HTML error page never ends up in memory or logs.
+const MaxErrorBodySize = 8 << 10
+
+// CheckResponse returns nil for 2xx responses. Otherwise it reads up to
+// MaxErrorBodySize bytes of the body and returns an *errorsx.GraphError when
+// the body carries a Graph error envelope, or an *errorsx.HTTPError (e.g. for
+// CDN failures on media downloads). Both carry the fb-trace-id. The caller
+// still owns, and must close, resp.Body.
+func CheckResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	var body []byte
+	if resp.Body != nil {
+		body, _ = io.ReadAll(io.LimitReader(resp.Body, MaxErrorBodySize))
+	}
+	if ge := errorsx.TryParseGraphError(resp, body); ge.Detail.Message != "" || ge.Detail.Code != 0 {
+		return ge
+	}
+	return errorsx.NewHTTPErrorFromResponse(resp, body)
+}
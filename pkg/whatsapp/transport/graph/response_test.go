@@ -0,0 +1,57 @@
+package graph
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/errorsx"
+)
+
+func errorResponse(status int, header http.Header, body string) *http.Response {
+	req, _ := http.NewRequest(http.MethodGet, "https://graph.example/v20.0/x", nil)
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{StatusCode: status, Status: http.StatusText(status), Header: header, Body: io.NopCloser(strings.NewReader(body)), Request: req}
+}
+
+func TestCheckResponse_Success(t *testing.T) {
+	if err := CheckResponse(errorResponse(204, nil, "")); err != nil {
+		t.Fatalf("2xx should pass, got %v", err)
+	}
+}
+
+func TestCheckResponse_GraphError(t *testing.T) {
+	resp := errorResponse(400, http.Header{"X-Fb-Trace-Id": {"hdr"}},
+		`{"error":{"message":"Template name does not exist","type":"OAuthException","code":132001,"fbtrace_id":"payload"}}`)
+	err := CheckResponse(resp)
+	var ge *errorsx.GraphError
+	if !errors.As(err, &ge) {
+		t.Fatalf("expected *GraphError, got %T", err)
+	}
+	if ge.HTTP.FBTraceID != "payload" || ge.HTTP.StatusCode != 400 {
+		t.Fatalf("unexpected error context: %+v", ge.HTTP)
+	}
+	if !errors.Is(err, errorsx.ErrTemplateNotFound) {
+		t.Fatalf("expected ErrTemplateNotFound")
+	}
+}
+
+func TestCheckResponse_HTTPErrorTruncated(t *testing.T) {
+	page := "<html>" + strings.Repeat("x", 2*MaxErrorBodySize) + "</html>"
+	err := CheckResponse(errorResponse(502, http.Header{"X-Fb-Trace-Id": {"tid"}}, page))
+	var ge *errorsx.GraphError
+	if errors.As(err, &ge) {
+		t.Fatalf("non-Graph body should not produce a GraphError")
+	}
+	var he *errorsx.HTTPError
+	if !errors.As(err, &he) {
+		t.Fatalf("expected *HTTPError, got %T", err)
+	}
+	if len(he.Body) != MaxErrorBodySize || he.FBTraceID != "tid" || !errorsx.IsRetryable(err) {
+		t.Fatalf("unexpected HTTPError: len=%d trace=%q", len(he.Body), he.FBTraceID)
+	}
+}
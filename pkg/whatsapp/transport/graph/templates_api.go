@@ -0,0 +1,106 @@
+package graph
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
+)
+
+// NewTemplatesListRequest builds GET /{Version}/{WABA-ID}/message_templates with
+// the filters and cursor from p encoded as query parameters.
+func NewTemplatesListRequest(ctx context.Context, base, version, wabaID string, p domain.TemplateListParams) (*http.Request, error) {
+	q := url.Values{}
+	if p.Status != "" {
+		q.Set("status", string(p.Status))
+	}
+	if p.Category != "" {
+		q.Set("category", string(p.Category))
+	}
+	if p.Language != "" {
+		q.Set("language", p.Language)
+	}
+	if p.Name != "" {
+		q.Set("name", p.Name)
+	}
+	if p.Limit > 0 {
+		q.Set("limit", strconv.Itoa(p.Limit))
+	}
+	if p.After != "" {
+		q.Set("after", p.After)
+	}
+	if p.Before != "" {
+		q.Set("before", p.Before)
+	}
+	if len(p.Fields) > 0 {
+		q.Set("fields", strings.Join(p.Fields, ","))
+	}
+
+	u := MessageTemplatesEndpoint(base, version, wabaID)
+	if len(q) > 0 {
+		u += "?" + q.Encode()
+	}
+	return newJSONRequest(ctx, http.MethodGet, u, nil)
+}
+
+// NewTemplateGetRequest builds GET /{Version}/{Template-ID}.
+func NewTemplateGetRequest(ctx context.Context, base, version, templateID string) (*http.Request, error) {
+	return newJSONRequest(ctx, http.MethodGet, MessageTemplateEndpoint(base, version, templateID), nil)
+}
+
+// NewTemplateCreateRequest builds POST /{Version}/{WABA-ID}/message_templates.
+func NewTemplateCreateRequest(ctx context.Context, base, version, wabaID string, t *domain.MessageTemplate) (*http.Request, error) {
+	return newJSONRequest(ctx, http.MethodPost, MessageTemplatesEndpoint(base, version, wabaID), t)
+}
+
+// NewTemplateEditRequest builds POST /{Version}/{Template-ID}.
+func NewTemplateEditRequest(ctx context.Context, base, version, templateID string, e domain.MessageTemplateEdit) (*http.Request, error) {
+	return newJSONRequest(ctx, http.MethodPost, MessageTemplateEndpoint(base, version, templateID), e)
+}
+
+// NewTemplateDeleteRequest builds DELETE /{Version}/{WABA-ID}/message_templates?name=...
+// When hsmID is set only that template (one language) is deleted; otherwise every
+// language of the named template is removed.
+func NewTemplateDeleteRequest(ctx context.Context, base, version, wabaID, name, hsmID string) (*http.Request, error) {
+	q := url.Values{}
+	q.Set("name", name)
+	if hsmID != "" {
+		q.Set("hsm_id", hsmID)
+	}
+	u := MessageTemplatesEndpoint(base, version, wabaID) + "?" + q.Encode()
+	return newJSONRequest(ctx, http.MethodDelete, u, nil)
+}
+
+// newJSONRequest builds a request with an optional JSON body. The body is kept
+// rewindable so the HTTPDoer can retry it.
+func newJSONRequest(ctx context.Context, method, u string, payload any) (*http.Request, error) {
+	var body []byte
+	if payload != nil {
+		b, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("encode request: %w", err)
+		}
+		body = b
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+		req.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(body)), nil }
+	} else {
+		req.Body = http.NoBody
+		req.ContentLength = 0
+	}
+	return req, nil
+}
@@ -0,0 +1,85 @@
+package graph
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/diegoyosiura/whatsapp-sdk-go/pkg/whatsapp/domain"
+)
+
+func TestNewTemplatesListRequest(t *testing.T) {
+	p := domain.TemplateListParams{
+		Status:   domain.TemplateStatusApproved,
+		Category: domain.TemplateCategoryUtility,
+		Language: "pt_BR",
+		Name:     "order_update",
+		Limit:    25,
+		After:    "cursor",
+		Fields:   []string{"name", "status"},
+	}
+	req, err := NewTemplatesListRequest(context.Background(), DefaultBaseURL, "v1", "waba", p)
+	if err != nil {
+		t.Fatalf("NewTemplatesListRequest error: %v", err)
+	}
+	if req.Method != http.MethodGet {
+		t.Fatalf("expected GET got %s", req.Method)
+	}
+	if req.URL.Path != "/v1/waba/message_templates" {
+		t.Fatalf("unexpected path %s", req.URL.Path)
+	}
+	q := req.URL.Query()
+	want := map[string]string{
+		"status": "APPROVED", "category": "UTILITY", "language": "pt_BR", "name": "order_update",
+		"limit": "25", "after": "cursor", "fields": "name,status",
+	}
+	for k, v := range want {
+		if got := q.Get(k); got != v {
+			t.Fatalf("query %s = %q, want %q", k, got, v)
+		}
+	}
+	if q.Has("before") {
+		t.Fatalf("empty filter must be omitted")
+	}
+}
+
+func TestNewTemplateCreateRequest(t *testing.T) {
+	tmpl := &domain.MessageTemplate{Name: "welcome", Language: "en", Category: domain.TemplateCategoryUtility}
+	req, err := NewTemplateCreateRequest(context.Background(), DefaultBaseURL, "v1", "waba", tmpl)
+	if err != nil {
+		t.Fatalf("NewTemplateCreateRequest error: %v", err)
+	}
+	if req.Method != http.MethodPost || req.Header.Get("Content-Type") != "application/json" {
+		t.Fatalf("unexpected method/content-type %s %q", req.Method, req.Header.Get("Content-Type"))
+	}
+	b, _ := io.ReadAll(req.Body)
+	if !strings.Contains(string(b), `"name":"welcome"`) {
+		t.Fatalf("body not encoded: %s", b)
+	}
+	rc, err := req.GetBody()
+	if err != nil {
+		t.Fatalf("GetBody error: %v", err)
+	}
+	b2, _ := io.ReadAll(rc)
+	if string(b2) != string(b) {
+		t.Fatalf("GetBody mismatch")
+	}
+}
+
+func TestNewTemplateDeleteRequest(t *testing.T) {
+	req, err := NewTemplateDeleteRequest(context.Background(), DefaultBaseURL, "v1", "waba", "welcome", "123")
+	if err != nil {
+		t.Fatalf("NewTemplateDeleteRequest error: %v", err)
+	}
+	if req.Method != http.MethodDelete {
+		t.Fatalf("expected DELETE got %s", req.Method)
+	}
+	if q := req.URL.Query(); q.Get("name") != "welcome" || q.Get("hsm_id") != "123" {
+		t.Fatalf("unexpected query %s", req.URL.RawQuery)
+	}
+	if req.ContentLength != 0 {
+		t.Fatalf("DELETE must not carry a body")
+	}
+}
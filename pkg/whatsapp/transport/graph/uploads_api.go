@@ -0,0 +1,40 @@
+package graph
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// NewUploadSessionRequest builds POST /{Version}/{App-ID}/uploads, which opens a
+// Resumable Upload API session for a file of fileLength bytes.
+func NewUploadSessionRequest(ctx context.Context, base, version, appID, fileName string, fileLength int64, fileType string) (*http.Request, error) {
+	q := url.Values{}
+	q.Set("file_name", fileName)
+	q.Set("file_length", strconv.FormatInt(fileLength, 10))
+	q.Set("file_type", fileType)
+	return newJSONRequest(ctx, http.MethodPost, UploadSessionsEndpoint(base, version, appID)+"?"+q.Encode(), nil)
+}
+
+// NewUploadStatusRequest builds GET /{Version}/{Upload-Session-ID}, which reports
+// the offset the server has received so far.
+func NewUploadStatusRequest(ctx context.Context, base, version, sessionID string) (*http.Request, error) {
+	return newJSONRequest(ctx, http.MethodGet, UploadSessionEndpoint(base, version, sessionID), nil)
+}
+
+// NewUploadChunkRequest builds POST /{Version}/{Upload-Session-ID} sending chunk
+// as the bytes starting at offset.
+func NewUploadChunkRequest(ctx context.Context, base, version, sessionID string, offset int64, chunk []byte) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, UploadSessionEndpoint(base, version, sessionID), bytes.NewReader(chunk))
+	if err != nil {
+		return nil, err
+	}
+	req.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(chunk)), nil }
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("file_offset", strconv.FormatInt(offset, 10))
+	return req, nil
+}
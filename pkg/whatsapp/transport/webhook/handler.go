@@ -1,6 +1,7 @@
 package webhook
 
 import (
+	"errors"
 	"io"
 	"log"
 	"net/http"
@@ -10,16 +11,26 @@ import (
 
 // Handler is an HTTP adapter for WhatsApp webhooks.
 // It uses WebhookService for validation/parsing and optionally dispatches
-// events to a WebhookDispatcher.
+// events to a WebhookDispatcher; a handler error answers 500 so Meta
+// redelivers the event. When Async is set, events are queued on it
+// and acknowledged before the handlers run; a full or shut down queue answers
+// 503 so Meta redelivers the event later.
 type Handler struct {
 	Service    *services.WebhookService
 	Dispatcher *services.WebhookDispatcher
+	Async      *services.AsyncDispatcher
 }
 
 func NewHandler(svc *services.WebhookService, dispatcher *services.WebhookDispatcher) *Handler {
 	return &Handler{Service: svc, Dispatcher: dispatcher}
 }
 
+// WithAsync dispatches events through a instead of calling Dispatcher inline.
+func (h *Handler) WithAsync(a *services.AsyncDispatcher) *Handler {
+	h.Async = a
+	return h
+}
+
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	switch r.Method {
@@ -57,9 +68,22 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, "invalid payload", http.StatusBadRequest)
 			return
 		}
-		if h.Dispatcher != nil {
-			log.Printf("invalid dispatcher")
-			h.Dispatcher.Dispatch(event, r.Header)
+		switch {
+		case h.Async != nil:
+			if err := h.Async.Enqueue(ctx, event, r.Header); err != nil {
+				log.Printf("webhook not queued: %v", err)
+				if errors.Is(err, services.ErrQueueFull) || errors.Is(err, services.ErrDispatcherClosed) {
+					w.Header().Set("Retry-After", "1")
+				}
+				http.Error(w, "busy", http.StatusServiceUnavailable)
+				return
+			}
+		case h.Dispatcher != nil:
+			if err := h.Dispatcher.DispatchContext(ctx, event, r.Header); err != nil {
+				log.Printf("webhook handler: %v", err)
+				http.Error(w, "handler failed", http.StatusInternalServerError)
+				return
+			}
 		}
 		w.WriteHeader(http.StatusOK)
 		_, _ = io.WriteString(w, "ok")
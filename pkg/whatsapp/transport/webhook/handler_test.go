@@ -2,9 +2,11 @@ package webhook_test
 
 import (
 	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -72,3 +74,71 @@ func TestHandler_PostDispatch(t *testing.T) {
 		t.Fatalf("dispatcher not invoked: %+v", fh.messages)
 	}
 }
+
+func TestHandler_PostAsync(t *testing.T) {
+	payload := []byte(`{"object":"whatsapp_business_account","entry":[{"id":"x","changes":[{"value":{"messages":[{"id":"m1"}]}}]}]}`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(payload)
+	sig := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	fp := &intfakes.FakeSecretsProvider{Secrets: map[ports.SecretKey]string{
+		ports.AppSecretKey: "secret",
+	}}
+	fh := &fakeHandler{}
+	async := services.NewAsyncDispatcher(services.NewWebhookDispatcher(fh), services.AsyncDispatcherOptions{Workers: 1})
+	h := webhook.NewHandler(services.NewWebhookService(fp), nil).WithAsync(async)
+
+	post := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(payload))
+		req.Header.Set("X-Hub-Signature-256", sig)
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+		return rr
+	}
+
+	if rr := post(); rr.Code != http.StatusOK {
+		t.Fatalf("unexpected status: %d", rr.Code)
+	}
+	if err := async.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(fh.messages) != 1 || fh.messages[0].ID != "m1" {
+		t.Fatalf("dispatcher not invoked: %+v", fh.messages)
+	}
+
+	// A closed dispatcher cannot take the event: Meta must redeliver it.
+	rr := post()
+	if rr.Code != http.StatusServiceUnavailable || rr.Header().Get("Retry-After") == "" {
+		t.Fatalf("after shutdown: code=%d headers=%v", rr.Code, rr.Header())
+	}
+}
+
+type failingHandler struct{}
+
+func (failingHandler) Always(context.Context, domain.WebhookEvent, http.Header) error { return nil }
+func (failingHandler) OnMessage(context.Context, domain.InboundMessage, domain.WebhookEvent, http.Header) error {
+	return errors.New("database down")
+}
+func (failingHandler) OnStatus(context.Context, domain.MessageStatus, domain.WebhookEvent, http.Header) error {
+	return nil
+}
+
+func TestHandler_PostHandlerError(t *testing.T) {
+	payload := []byte(`{"object":"whatsapp_business_account","entry":[{"id":"x","changes":[{"value":{"messages":[{"id":"m1"}]}}]}]}`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(payload)
+
+	fp := &intfakes.FakeSecretsProvider{Secrets: map[ports.SecretKey]string{
+		ports.AppSecretKey: "secret",
+	}}
+	h := webhook.NewHandler(services.NewWebhookService(fp), services.NewWebhookDispatcherV2(failingHandler{}))
+
+	req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(payload))
+	req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusInternalServerError {
+		t.Fatalf("unexpected status: %d, want 500 so Meta redelivers", rr.Code)
+	}
+}